@@ -0,0 +1,6 @@
+data "verifiedid_authorities" "all" {
+}
+
+output "contoso_did" {
+  value = data.verifiedid_authorities.all.authorities_by_name["Contoso"].did
+}
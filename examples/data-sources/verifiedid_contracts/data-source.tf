@@ -0,0 +1,10 @@
+data "verifiedid_authorities" "all" {
+}
+
+data "verifiedid_contracts" "contoso" {
+  authority_id = data.verifiedid_authorities.all.authorities_by_name["Contoso"].id
+}
+
+output "manifest_urls" {
+  value = { for name, contract in data.verifiedid_contracts.contoso.contracts_by_name : name => contract.manifest_url }
+}
@@ -0,0 +1,9 @@
+data "verifiedid_credentials" "alice" {
+  authority_id        = "00000000-0000-0000-0000-000000000000"
+  contract_id         = "00000000-0000-0000-0000-000000000000"
+  indexed_claim_value = "alice@contoso.com"
+}
+
+output "valid_credential_ids" {
+  value = [for c in data.verifiedid_credentials.alice.credentials : c.id if c.status == "valid"]
+}
@@ -0,0 +1,9 @@
+data "verifiedid_did_configuration" "contoso" {
+  authority_id = "00000000-0000-0000-0000-000000000000"
+  domain_url   = "https://www.contoso.com/"
+}
+
+resource "local_file" "did_configuration" {
+  filename = "${path.module}/site/.well-known/did-configuration.json"
+  content  = data.verifiedid_did_configuration.contoso.json
+}
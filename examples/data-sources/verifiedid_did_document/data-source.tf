@@ -0,0 +1,9 @@
+data "verifiedid_did_document" "contoso" {
+  authority_id = "00000000-0000-0000-0000-000000000000"
+  domain_url   = "https://www.contoso.com/"
+}
+
+resource "local_file" "did_document" {
+  filename = "${path.module}/site/.well-known/did.json"
+  content  = data.verifiedid_did_document.contoso.json
+}
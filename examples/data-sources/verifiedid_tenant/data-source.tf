@@ -0,0 +1,6 @@
+data "verifiedid_tenant" "current" {
+}
+
+output "verifiedid_onboarded" {
+  value = data.verifiedid_tenant.current.onboarded
+}
@@ -0,0 +1,31 @@
+ephemeral "verifiedid_issuance_request" "smoke_test" {
+  authority       = verifiedid_authority.example.did
+  manifest_url    = verifiedid_contract.example.manifest_url
+  credential_type = "VerifiedEmployee"
+  client_name     = "Contoso"
+
+  claims = {
+    certNumber = "12345"
+  }
+  pin = "1234"
+
+  callback = {
+    url   = "https://www.contoso.com/api/issuer/callback"
+    state = "smoke-test"
+    headers = {
+      api-key = var.callback_api_key
+    }
+  }
+}
+
+resource "terraform_data" "smoke_test" {
+  triggers_replace = verifiedid_contract.example.id
+
+  provisioner "local-exec" {
+    command = "./smoke-test.sh"
+    environment = {
+      REQUEST_ID  = ephemeral.verifiedid_issuance_request.smoke_test.request_id
+      REQUEST_URL = ephemeral.verifiedid_issuance_request.smoke_test.request_url
+    }
+  }
+}
@@ -9,9 +9,69 @@ terraform {
 provider "verifiedid" {
 }
 
-resource "verifiedid_resource" "credential_cert1" {
-    patch_as_full_body = true
-    #api_version = "beta"
-    url  = "verifiableCredentials/authorities/____YOUR_GUID_HERE_FOR_AUTHORITY____/contracts"
-    body = jsondecode(file("contract-demo.json"))
+resource "verifiedid_contract" "credential_cert1" {
+  authority_id = "____YOUR_GUID_HERE_FOR_AUTHORITY____"
+  name         = "DemoCertificate01"
+
+  rules = {
+    attestations = {
+      id_token_hints = [
+        {
+          required = true
+          mapping = [
+            {
+              input_claim  = "certNumber"
+              output_claim = "certNumber"
+              required     = true
+            },
+          ]
+        },
+      ]
+      self_issued = {
+        required = true
+        mapping = [
+          {
+            input_claim  = "displayName"
+            output_claim = "displayName"
+            required     = true
+          },
+        ]
+      }
+    }
+    validity_interval = 2592000
+    vc = {
+      type = ["Demo01"]
+    }
+  }
+
+  displays = {
+    "en-US" = {
+      card = {
+        title            = "For Demo 01 Title"
+        issued_by        = "__YOUR_ISSUER_NAME_HERE__"
+        description      = "Demo 01 Verified ID"
+        background_color = "#BDD0A7"
+        text_color       = "#000000"
+        logo = {
+          uri         = "https://___YOUR_LOGO_URI_HERE___"
+          description = "LogoDescription"
+        }
+      }
+      consent = {
+        title        = "Share Demo 01 Credentials"
+        instructions = "TODO"
+      }
+      claims = [
+        {
+          claim = "vc.credentialSubject.certNumber"
+          label = "Certificate Number"
+          type  = "String"
+        },
+      ]
+    }
+  }
+}
+
+output "manifest_url" {
+  value = verifiedid_contract.credential_cert1.manifest_url
 }
@@ -0,0 +1,2 @@
+# Verified ID authorities can be imported using the authority ID
+terraform import verifiedid_authority.example 00000000-0000-0000-0000-000000000000
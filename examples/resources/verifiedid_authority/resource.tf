@@ -0,0 +1,8 @@
+resource "verifiedid_authority" "example" {
+  name               = "Contoso"
+  linked_domain_urls = ["https://www.contoso.com/"]
+}
+
+output "did" {
+  value = verifiedid_authority.example.did
+}
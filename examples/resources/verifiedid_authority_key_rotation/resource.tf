@@ -0,0 +1,15 @@
+resource "verifiedid_authority" "example" {
+  name               = "Contoso"
+  linked_domain_urls = ["https://www.contoso.com/"]
+}
+
+resource "verifiedid_authority_key_rotation" "example" {
+  authority_id     = verifiedid_authority.example.id
+  domain_url       = "https://www.contoso.com/"
+  rotation_trigger = "2025-01"
+}
+
+resource "local_file" "did_document" {
+  filename = "${path.module}/site/.well-known/did.json"
+  content  = verifiedid_authority_key_rotation.example.did_document_json
+}
@@ -0,0 +1,2 @@
+# Verified ID contracts can be imported using the URL of the contract
+terraform import verifiedid_contract.example verifiableCredentials/authorities/00000000-0000-0000-0000-000000000000/contracts/00000000-0000-0000-0000-000000000000
@@ -0,0 +1,72 @@
+resource "verifiedid_authority" "example" {
+  name               = "Contoso"
+  linked_domain_urls = ["https://www.contoso.com/"]
+}
+
+resource "verifiedid_contract" "example" {
+  authority_id = verifiedid_authority.example.id
+  name         = "VerifiedEmployee"
+
+  rules = {
+    attestations = {
+      id_token_hints = [
+        {
+          required = true
+          mapping = [
+            {
+              input_claim  = "employeeId"
+              output_claim = "employeeId"
+              indexed      = true
+              required     = true
+            },
+          ]
+        },
+      ]
+      self_issued = {
+        mapping = [
+          {
+            input_claim  = "displayName"
+            output_claim = "displayName"
+          },
+        ]
+      }
+    }
+    validity_interval = 2592000
+    vc = {
+      type = ["VerifiedEmployee"]
+    }
+  }
+
+  displays = {
+    "en-US" = {
+      card = {
+        title            = "Verified Employee"
+        issued_by        = "Contoso"
+        background_color = "#BDD0A7"
+        text_color       = "#000000"
+        logo = {
+          uri         = "https://www.contoso.com/logo.png"
+          description = "Contoso logo"
+        }
+      }
+      consent = {
+        title        = "Do you want to get your Verified Employee credential?"
+        instructions = "Sign in with your account to get your card."
+      }
+      claims = [
+        {
+          claim = "vc.credentialSubject.employeeId"
+          label = "Employee ID"
+        },
+        {
+          claim = "vc.credentialSubject.displayName"
+          label = "Name"
+        },
+      ]
+    }
+  }
+}
+
+output "manifest_url" {
+  value = verifiedid_contract.example.manifest_url
+}
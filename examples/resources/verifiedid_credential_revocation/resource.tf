@@ -0,0 +1,9 @@
+resource "verifiedid_credential_revocation" "example" {
+  authority_id        = "00000000-0000-0000-0000-000000000000"
+  contract_id         = "00000000-0000-0000-0000-000000000000"
+  indexed_claim_value = "alice@contoso.com"
+}
+
+output "revoked_credential_ids" {
+  value = verifiedid_credential_revocation.example.revoked_credential_ids
+}
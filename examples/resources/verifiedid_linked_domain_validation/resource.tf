@@ -0,0 +1,21 @@
+resource "verifiedid_authority" "example" {
+  name               = "Contoso"
+  linked_domain_urls = ["https://www.contoso.com/"]
+}
+
+data "verifiedid_did_configuration" "example" {
+  authority_id = verifiedid_authority.example.id
+  domain_url   = "https://www.contoso.com/"
+}
+
+# Publish data.verifiedid_did_configuration.example.json at
+# https://www.contoso.com/.well-known/did-configuration.json before validating the domain.
+
+resource "verifiedid_linked_domain_validation" "example" {
+  authority_id = verifiedid_authority.example.id
+  domain_url   = "https://www.contoso.com/"
+
+  timeouts {
+    create = "15m"
+  }
+}
@@ -0,0 +1,12 @@
+resource "verifiedid_tenant_onboarding" "this" {
+  # Set to true only if destroying this resource should opt the tenant out of Verified ID,
+  # which deletes every authority and contract of the tenant.
+  allow_opt_out = false
+}
+
+resource "verifiedid_authority" "example" {
+  name               = "Contoso"
+  linked_domain_urls = ["https://www.contoso.com/"]
+
+  depends_on = [verifiedid_tenant_onboarding.this]
+}
@@ -0,0 +1,679 @@
+package acceptance
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/hashicorp/go-uuid"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// fakeApiPrefix is the prefix of the paths served by the fake server, the Admin API version.
+const fakeApiPrefix = "/v1.0/verifiableCredentials"
+
+// FakeServer is an in-memory implementation of the Verified ID Admin API. It implements onboarding, authorities,
+// contracts, the credentials search and revocation, the DID documents, the rotation of the signing keys and the
+// issuance requests of the Request Service API, so the resources and data sources can be tested end to end without a
+// tenant.
+type FakeServer struct {
+	*httptest.Server
+
+	lock        sync.Mutex
+	onboarded   bool
+	authorities map[string]*fakeAuthority
+	hosted      map[string]bool
+	faults      []*Fault
+	// credentialsPageSize is the number of credentials of a page of the search, every credential when 0.
+	credentialsPageSize int
+	// issuanceRequests are the bodies of the issuance requests created, in order.
+	issuanceRequests []map[string]interface{}
+}
+
+// Fault is an error response the fake server returns instead of handling the request.
+type Fault struct {
+	// Method is the HTTP method of the requests to fail, all methods when empty.
+	Method string
+	// Path is the prefix of the paths of the requests to fail, relative to `verifiableCredentials`, e.g. `authorities`.
+	Path string
+	// StatusCode is the status of the error response, 404, 409 and 429 have a matching error code.
+	StatusCode int
+	// Count is the number of requests to fail, every request when 0.
+	Count int
+}
+
+type fakeAuthority struct {
+	body      map[string]interface{}
+	didModel  map[string]interface{}
+	contracts map[string]*fakeContract
+	keys      int
+}
+
+type fakeContract struct {
+	body        map[string]interface{}
+	credentials []map[string]interface{}
+	// version is incremented by each change of the contract, it's returned as its ETag.
+	version int
+}
+
+// NewFakeServer starts a fake server for the test, it's closed when the test completes. The tenant is onboarded.
+func NewFakeServer(t *testing.T) *FakeServer {
+	s := &FakeServer{
+		onboarded:   true,
+		authorities: make(map[string]*fakeAuthority),
+		hosted:      make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+fakeApiPrefix+"/onboard", s.onboard)
+	mux.HandleFunc("POST "+fakeApiPrefix+"/optout", s.optOut)
+	mux.HandleFunc("GET "+fakeApiPrefix+"/authorities", s.listAuthorities)
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities", s.createAuthority)
+	mux.HandleFunc("GET "+fakeApiPrefix+"/authorities/{authorityId}", s.withAuthority(s.readAuthority))
+	mux.HandleFunc("PATCH "+fakeApiPrefix+"/authorities/{authorityId}", s.withAuthority(s.updateAuthority))
+	mux.HandleFunc("DELETE "+fakeApiPrefix+"/authorities/{authorityId}", s.withAuthority(s.deleteAuthority))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities/{authorityId}/updateLinkedDomains", s.withAuthority(s.updateLinkedDomains))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities/{authorityId}/generateDidDocument", s.withAuthority(s.generateDidDocument))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities/{authorityId}/generateWellknownDidConfiguration", s.withAuthority(s.generateDidConfiguration))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities/{authorityId}/validateWellKnownDidConfiguration", s.withAuthority(s.validateDidConfiguration))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities/{authorityId}/didInfo/signingKeys/rotate", s.withAuthority(s.rotateSigningKey))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities/{authorityId}/didInfo/synchronizeWithDidDocument", s.withAuthority(s.readAuthority))
+	mux.HandleFunc("GET "+fakeApiPrefix+"/authorities/{authorityId}/contracts", s.withAuthority(s.listContracts))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities/{authorityId}/contracts", s.withAuthority(s.createContract))
+	mux.HandleFunc("GET "+fakeApiPrefix+"/authorities/{authorityId}/contracts/{contractId}", s.withContract(s.readContract))
+	mux.HandleFunc("PATCH "+fakeApiPrefix+"/authorities/{authorityId}/contracts/{contractId}", s.withContract(s.updateContract))
+	mux.HandleFunc("DELETE "+fakeApiPrefix+"/authorities/{authorityId}/contracts/{contractId}", s.withContract(s.deleteContract))
+	mux.HandleFunc("GET "+fakeApiPrefix+"/authorities/{authorityId}/contracts/{contractId}/credentials", s.withContract(s.searchCredentials))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/authorities/{authorityId}/contracts/{contractId}/credentials/{credentialId}/revoke", s.withContract(s.revokeCredential))
+	mux.HandleFunc("POST "+fakeApiPrefix+"/createIssuanceRequest", s.createIssuanceRequest)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeFakeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("%s %s is not implemented by the fake server", r.Method, r.URL.Path))
+	})
+
+	s.Server = httptest.NewServer(s.handle(mux))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// InjectFault makes the server fail the matching requests with the fault status code.
+func (s *FakeServer) InjectFault(fault Fault) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// SetOnboarded sets whether the tenant is onboarded, the authorities can't be used when it isn't.
+func (s *FakeServer) SetOnboarded(onboarded bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.onboarded = onboarded
+}
+
+// SetCredentialsPageSize sets the number of credentials of a page of the search, the next pages are linked with an
+// `@odata.nextLink`. Every credential is returned in a single page when it's 0.
+func (s *FakeServer) SetCredentialsPageSize(pageSize int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.credentialsPageSize = pageSize
+}
+
+// HostDidConfiguration marks the well-known DID configuration of the domain as hosted, so its validation succeeds.
+func (s *FakeServer) HostDidConfiguration(domainUrl string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.hosted[fakeOrigin(domainUrl)] = true
+}
+
+// IssueCredential issues a valid credential of the contract for the value of its indexed claim and returns its ID.
+func (s *FakeServer) IssueCredential(authorityId, contractId, indexedClaimValue string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	authority, ok := s.authorities[authorityId]
+	if !ok {
+		return "", fmt.Errorf("authority %q was not found", authorityId)
+	}
+	contract, ok := authority.contracts[contractId]
+	if !ok {
+		return "", fmt.Errorf("contract %q was not found", contractId)
+	}
+
+	id := fakeId()
+	now := time.Now().UTC()
+	contract.credentials = append(contract.credentials, map[string]interface{}{
+		"id":             id,
+		"status":         "valid",
+		"issuedAt":       now.Format(time.RFC3339),
+		"expiresAt":      now.AddDate(1, 0, 0).Format(time.RFC3339),
+		"indexClaimHash": utils.IndexClaimHash(contractId, indexedClaimValue),
+	})
+	return id, nil
+}
+
+// ChangeAuthorityDidModel sets a field of the DID model of the authority as if it was changed outside Terraform.
+func (s *FakeServer) ChangeAuthorityDidModel(authorityId, key string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	authority, ok := s.authorities[authorityId]
+	if !ok {
+		return fmt.Errorf("authority %q was not found", authorityId)
+	}
+	authority.didModel[key] = value
+	return nil
+}
+
+// ChangeContract sets a field of the contract as if it was changed outside Terraform, which changes its ETag.
+func (s *FakeServer) ChangeContract(authorityId, contractId, key string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	authority, ok := s.authorities[authorityId]
+	if !ok {
+		return fmt.Errorf("authority %q was not found", authorityId)
+	}
+	contract, ok := authority.contracts[contractId]
+	if !ok {
+		return fmt.Errorf("contract %q was not found", contractId)
+	}
+	contract.body[key] = value
+	contract.version++
+	return nil
+}
+
+// IssuanceRequests returns the bodies of the issuance requests created, in order.
+func (s *FakeServer) IssuanceRequests() []map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]map[string]interface{}{}, s.issuanceRequests...)
+}
+
+// Credential returns a credential used to call the fake server, which accepts any access token.
+func (s *FakeServer) Credential() azcore.TokenCredential {
+	return fakeCredential{}
+}
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{
+		Token:     "fake",
+		ExpiresOn: time.Now().Add(time.Hour),
+	}, nil
+}
+
+// handle checks the access token, the injected faults and the onboarding status before serving the request.
+func (s *FakeServer) handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeFakeError(w, http.StatusUnauthorized, "Unauthorized", "The request has no access token.")
+			return
+		}
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, fakeApiPrefix), "/")
+		for i, fault := range s.faults {
+			if (fault.Method != "" && !strings.EqualFold(fault.Method, r.Method)) || !strings.HasPrefix(path, fault.Path) {
+				continue
+			}
+			if fault.Count > 0 {
+				if fault.Count--; fault.Count == 0 {
+					s.faults = append(s.faults[:i], s.faults[i+1:]...)
+				}
+			}
+			switch fault.StatusCode {
+			case http.StatusNotFound:
+				writeFakeError(w, fault.StatusCode, "NotFound", "The resource was not found.")
+			case http.StatusConflict:
+				writeFakeError(w, fault.StatusCode, "Conflict", "The resource was modified by another request.")
+			case http.StatusTooManyRequests:
+				w.Header().Set("Retry-After", "1")
+				writeFakeError(w, fault.StatusCode, "TooManyRequests", "The request was throttled.")
+			default:
+				writeFakeError(w, fault.StatusCode, http.StatusText(fault.StatusCode), "The fault was injected.")
+			}
+			return
+		}
+
+		if !s.onboarded && strings.HasPrefix(path, "authorities") {
+			writeFakeError(w, http.StatusForbidden, "TenantNotOnboarded", "The tenant is not onboarded to Verified ID.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *FakeServer) withAuthority(next func(http.ResponseWriter, *http.Request, *fakeAuthority)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authority, ok := s.authorities[r.PathValue("authorityId")]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Authority %q was not found.", r.PathValue("authorityId")))
+			return
+		}
+		next(w, r, authority)
+	}
+}
+
+func (s *FakeServer) withContract(next func(http.ResponseWriter, *http.Request, *fakeAuthority, *fakeContract)) http.HandlerFunc {
+	return s.withAuthority(func(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+		contract, ok := authority.contracts[r.PathValue("contractId")]
+		if !ok {
+			writeFakeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Contract %q was not found.", r.PathValue("contractId")))
+			return
+		}
+		next(w, r, authority, contract)
+	})
+}
+
+func (s *FakeServer) onboard(w http.ResponseWriter, r *http.Request) {
+	s.onboarded = true
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *FakeServer) optOut(w http.ResponseWriter, r *http.Request) {
+	s.onboarded = false
+	s.authorities = make(map[string]*fakeAuthority)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *FakeServer) listAuthorities(w http.ResponseWriter, r *http.Request) {
+	value := make([]interface{}, 0, len(s.authorities))
+	for _, authority := range s.authorities {
+		value = append(value, authority.json())
+	}
+	writeFakeJson(w, http.StatusOK, map[string]interface{}{"value": value})
+}
+
+func (s *FakeServer) createAuthority(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+
+	if _, ok := body["linkedDomainUrls"]; ok {
+		writeFakeError(w, http.StatusBadRequest, "BadRequest", "The authority is created with a single linkedDomainUrl.")
+		return
+	}
+
+	id := fakeId()
+	linkedDomainUrls := make([]interface{}, 0)
+	if v, ok := body["linkedDomainUrl"].(string); ok && v != "" {
+		linkedDomainUrls = append(linkedDomainUrls, v)
+	}
+	did := "did:ion:" + strings.ReplaceAll(id, "-", "")
+	if body["didMethod"] == "web" && len(linkedDomainUrls) != 0 {
+		did = "did:web:" + strings.TrimPrefix(fakeOrigin(fmt.Sprint(linkedDomainUrls[0])), "https://")
+	}
+
+	authority := &fakeAuthority{
+		body: map[string]interface{}{
+			"id":     id,
+			"name":   body["name"],
+			"status": "Enabled",
+		},
+		didModel: map[string]interface{}{
+			"did":               did,
+			"linkedDomainUrls":  linkedDomainUrls,
+			"didDocumentStatus": "published",
+		},
+		contracts: make(map[string]*fakeContract),
+	}
+	if v, ok := body["keyVaultMetadata"]; ok {
+		authority.body["keyVaultMetadata"] = v
+	}
+	authority.rotate()
+	s.authorities[id] = authority
+	writeFakeJson(w, http.StatusCreated, authority.json())
+}
+
+func (s *FakeServer) readAuthority(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	writeFakeJson(w, http.StatusOK, authority.json())
+}
+
+func (s *FakeServer) updateAuthority(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	var body map[string]interface{}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+	for key, v := range body {
+		if key != "name" {
+			writeFakeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("The property %q of an authority can't be updated.", key))
+			return
+		}
+		authority.body["name"] = v
+	}
+	writeFakeJson(w, http.StatusOK, authority.json())
+}
+
+func (s *FakeServer) updateLinkedDomains(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	var body struct {
+		DomainUrls []string `json:"domainUrls"`
+	}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+	if len(body.DomainUrls) == 0 {
+		writeFakeError(w, http.StatusBadRequest, "BadRequest", "The domainUrls are required.")
+		return
+	}
+	linkedDomainUrls := make([]interface{}, 0, len(body.DomainUrls))
+	for _, v := range body.DomainUrls {
+		linkedDomainUrls = append(linkedDomainUrls, v)
+	}
+	authority.didModel["linkedDomainUrls"] = linkedDomainUrls
+	authority.didModel["linkedDomainsVerified"] = false
+	writeFakeJson(w, http.StatusOK, authority.json())
+}
+
+func (s *FakeServer) deleteAuthority(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	delete(s.authorities, r.PathValue("authorityId"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *FakeServer) generateDidDocument(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	var body map[string]interface{}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+
+	did := authority.didModel["did"].(string)
+	verificationMethods := make([]interface{}, 0)
+	for i := 1; i <= authority.keys; i++ {
+		verificationMethods = append(verificationMethods, map[string]interface{}{
+			"id":         fmt.Sprintf("%s#key-%d", did, i),
+			"type":       "JsonWebKey2020",
+			"controller": did,
+			"publicKeyJwk": map[string]interface{}{
+				"kty": "EC",
+				"crv": "secp256k1",
+				"x":   base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("x-%s-%d", did, i))),
+				"y":   base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("y-%s-%d", did, i))),
+			},
+		})
+	}
+	writeFakeJson(w, http.StatusOK, map[string]interface{}{
+		"@context":           []interface{}{"https://www.w3.org/ns/did/v1"},
+		"id":                 did,
+		"verificationMethod": verificationMethods,
+		"service": []interface{}{
+			map[string]interface{}{
+				"id":   did + "#linkeddomains",
+				"type": "LinkedDomains",
+				"serviceEndpoint": map[string]interface{}{
+					"origins": []interface{}{body["domainUrl"]},
+				},
+			},
+		},
+	})
+}
+
+func (s *FakeServer) generateDidConfiguration(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	var body map[string]interface{}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+
+	encode := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	did := authority.didModel["did"].(string)
+	jwt := strings.Join([]string{
+		encode(map[string]interface{}{"alg": "ES256K", "kid": did + "#key-1"}),
+		encode(map[string]interface{}{"iss": did, "sub": did, "vc": map[string]interface{}{"credentialSubject": map[string]interface{}{"id": did, "origin": body["domainUrl"]}}}),
+		encode("signature"),
+	}, ".")
+	writeFakeJson(w, http.StatusOK, map[string]interface{}{
+		"@context":    "https://identity.foundation/.well-known/did-configuration/v1",
+		"linked_dids": []interface{}{jwt},
+	})
+}
+
+func (s *FakeServer) validateDidConfiguration(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	var body map[string]interface{}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+
+	domainUrl := fmt.Sprint(body["domainUrl"])
+	if !s.hosted[fakeOrigin(domainUrl)] {
+		writeFakeError(w, http.StatusBadRequest, "DidConfigurationNotFound", fmt.Sprintf("The DID configuration of %q could not be found.", domainUrl))
+		return
+	}
+	authority.didModel["linkedDomainsVerified"] = true
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *FakeServer) rotateSigningKey(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	authority.rotate()
+	writeFakeJson(w, http.StatusOK, authority.json())
+}
+
+func (s *FakeServer) listContracts(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	value := make([]interface{}, 0, len(authority.contracts))
+	for _, contract := range authority.contracts {
+		value = append(value, contract.body)
+	}
+	writeFakeJson(w, http.StatusOK, map[string]interface{}{"value": value})
+}
+
+func (s *FakeServer) createContract(w http.ResponseWriter, r *http.Request, authority *fakeAuthority) {
+	var body map[string]interface{}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+	for _, contract := range authority.contracts {
+		if contract.body["name"] == body["name"] {
+			writeFakeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("A contract named %q already exists.", body["name"]))
+			return
+		}
+	}
+
+	id := fakeId()
+	body["id"] = id
+	body["status"] = "Enabled"
+	body["manifestUrl"] = fmt.Sprintf("%s/tenants/fake/verifiableCredentials/contracts/%s/manifest", s.URL, id)
+	contract := &fakeContract{
+		body:        body,
+		credentials: make([]map[string]interface{}, 0),
+		version:     1,
+	}
+	authority.contracts[id] = contract
+	w.Header().Set("ETag", contract.etag())
+	writeFakeJson(w, http.StatusCreated, body)
+}
+
+func (s *FakeServer) readContract(w http.ResponseWriter, r *http.Request, authority *fakeAuthority, contract *fakeContract) {
+	w.Header().Set("ETag", contract.etag())
+	writeFakeJson(w, http.StatusOK, contract.body)
+}
+
+func (s *FakeServer) updateContract(w http.ResponseWriter, r *http.Request, authority *fakeAuthority, contract *fakeContract) {
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != "*" && ifMatch != contract.etag() {
+		writeFakeError(w, http.StatusPreconditionFailed, "PreconditionFailed", "The contract was changed since it was read.")
+		return
+	}
+	var body map[string]interface{}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+	for key, value := range body {
+		if key != "id" && key != "manifestUrl" {
+			contract.body[key] = value
+		}
+	}
+	contract.version++
+	w.Header().Set("ETag", contract.etag())
+	writeFakeJson(w, http.StatusOK, contract.body)
+}
+
+func (s *FakeServer) deleteContract(w http.ResponseWriter, r *http.Request, authority *fakeAuthority, contract *fakeContract) {
+	delete(authority.contracts, r.PathValue("contractId"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *FakeServer) searchCredentials(w http.ResponseWriter, r *http.Request, authority *fakeAuthority, contract *fakeContract) {
+	query := r.URL.Query()
+	hash := strings.TrimSpace(strings.TrimPrefix(query.Get("filter"), "indexclaimhash eq"))
+	value := make([]interface{}, 0)
+	for _, credential := range contract.credentials {
+		if credential["indexClaimHash"] == hash {
+			value = append(value, credential)
+		}
+	}
+	// The search responds with 404 when no credentials match the hash.
+	if len(value) == 0 {
+		writeFakeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("No credentials were found for index claim hash %q.", hash))
+		return
+	}
+
+	page := map[string]interface{}{"value": value}
+	if s.credentialsPageSize > 0 {
+		skip, _ := strconv.Atoi(query.Get("$skiptoken"))
+		end := min(skip+s.credentialsPageSize, len(value))
+		page["value"] = value[min(skip, end):end]
+		if end < len(value) {
+			query.Set("$skiptoken", strconv.Itoa(end))
+			page["@odata.nextLink"] = fmt.Sprintf("http://%s%s?%s", r.Host, r.URL.Path, query.Encode())
+		}
+	}
+	writeFakeJson(w, http.StatusOK, page)
+}
+
+func (s *FakeServer) revokeCredential(w http.ResponseWriter, r *http.Request, authority *fakeAuthority, contract *fakeContract) {
+	for _, credential := range contract.credentials {
+		if credential["id"] == r.PathValue("credentialId") {
+			credential["status"] = "revoked"
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Credential %q was not found.", r.PathValue("credentialId")))
+}
+
+func (s *FakeServer) createIssuanceRequest(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if !readFakeJson(w, r, &body) {
+		return
+	}
+
+	var authority *fakeAuthority
+	for _, a := range s.authorities {
+		if a.didModel["did"] == body["authority"] {
+			authority = a
+		}
+	}
+	if authority == nil {
+		writeFakeError(w, http.StatusBadRequest, "badOrMissingField", fmt.Sprintf("The authority %q was not found.", body["authority"]))
+		return
+	}
+	var contract *fakeContract
+	for _, c := range authority.contracts {
+		if c.body["manifestUrl"] == body["manifest"] {
+			contract = c
+		}
+	}
+	if contract == nil {
+		writeFakeError(w, http.StatusBadRequest, "badOrMissingField", fmt.Sprintf("The manifest %q was not found.", body["manifest"]))
+		return
+	}
+	callback, _ := body["callback"].(map[string]interface{})
+	if callback["url"] == nil || callback["url"] == "" {
+		writeFakeError(w, http.StatusBadRequest, "badOrMissingField", "The callback URL is missing.")
+		return
+	}
+	registration, _ := body["registration"].(map[string]interface{})
+	if registration["clientName"] == nil || registration["clientName"] == "" {
+		writeFakeError(w, http.StatusBadRequest, "badOrMissingField", "The registration client name is missing.")
+		return
+	}
+
+	s.issuanceRequests = append(s.issuanceRequests, body)
+	id := fakeId()
+	response := map[string]interface{}{
+		"requestId": id,
+		"url":       fmt.Sprintf("openid-vc://?request_uri=%s%s/request/%s", s.URL, fakeApiPrefix, id),
+		"expiry":    time.Now().Add(5 * time.Minute).Unix(),
+	}
+	if includeQRCode, _ := body["includeQRCode"].(bool); includeQRCode {
+		response["qrCode"] = "data:image/png;base64,iVBORw0KGgo="
+	}
+	writeFakeJson(w, http.StatusCreated, response)
+}
+
+// rotate adds a new signing key to the authority.
+func (a *fakeAuthority) rotate() {
+	a.keys++
+	signingKeys := make([]interface{}, 0, a.keys)
+	for i := 1; i <= a.keys; i++ {
+		signingKeys = append(signingKeys, fmt.Sprintf("https://fake.vault.azure.net/keys/issuerSigningKey-%s/%d", a.body["id"], i))
+	}
+	a.didModel["signingKeys"] = signingKeys
+}
+
+func (a *fakeAuthority) json() map[string]interface{} {
+	out := make(map[string]interface{}, len(a.body)+1)
+	for key, value := range a.body {
+		out[key] = value
+	}
+	out["didModel"] = a.didModel
+	return out
+}
+
+func (c *fakeContract) etag() string {
+	return fmt.Sprintf(`W/"%d"`, c.version)
+}
+
+func fakeId() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// fakeOrigin returns the scheme and host of a domain URL, so `https://contoso.com` and `https://contoso.com/` match.
+func fakeOrigin(domainUrl string) string {
+	u, err := url.Parse(domainUrl)
+	if err != nil {
+		return domainUrl
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+}
+
+func readFakeJson(w http.ResponseWriter, r *http.Request, out interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("The request body is invalid: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeFakeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeFakeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeFakeJson(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
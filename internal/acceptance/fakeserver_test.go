@@ -0,0 +1,277 @@
+package acceptance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+func newFakeServerTestClient(t *testing.T) (*FakeServer, *clients.MSGraphClient) {
+	s := NewFakeServer(t)
+	client := &clients.Client{}
+	if err := client.Build(context.Background(), &clients.Option{Cred: s.Credential(), Endpoint: s.URL}); err != nil {
+		t.Fatalf("building client: %v", err)
+	}
+	return s, client.VerifiedIDClient
+}
+
+func TestFakeServer_Authority(t *testing.T) {
+	s, client := newFakeServerTestClient(t)
+	ctx := context.Background()
+	options := clients.DefaultRequestOptions()
+
+	body, err := client.Create(ctx, "verifiableCredentials/authorities", "v1.0", map[string]interface{}{
+		"name":            "Contoso",
+		"didMethod":       "web",
+		"linkedDomainUrl": "https://www.contoso.com/",
+	}, options)
+	if err != nil {
+		t.Fatalf("creating authority: %v", err)
+	}
+	authority := body.(map[string]interface{})
+	id := authority["id"].(string)
+	if did := authority["didModel"].(map[string]interface{})["did"]; did != "did:web:www.contoso.com" {
+		t.Errorf("unexpected DID %q", did)
+	}
+
+	url := fmt.Sprintf("verifiableCredentials/authorities/%s", id)
+	if _, err := client.Update(ctx, url, "v1.0", map[string]interface{}{"linkedDomainUrls": []string{"https://www.fabrikam.com/"}}, options); !utils.ResponseErrorWasStatusCode(err, http.StatusBadRequest) {
+		t.Errorf("expected the update of the linked domains with a PATCH to fail, got: %v", err)
+	}
+	body, err = client.Action(ctx, http.MethodPost, url+"/updateLinkedDomains", "v1.0", map[string]interface{}{"domainUrls": []string{"https://www.contoso.com/", "https://www.fabrikam.com/"}}, options)
+	if err != nil {
+		t.Fatalf("updating the linked domains: %v", err)
+	}
+	if domains := body.(map[string]interface{})["didModel"].(map[string]interface{})["linkedDomainUrls"].([]interface{}); len(domains) != 2 {
+		t.Errorf("expected 2 linked domains, got %v", domains)
+	}
+	if _, err := client.Action(ctx, http.MethodPost, url+"/validateWellKnownDidConfiguration", "v1.0", map[string]interface{}{"domainUrl": "https://www.contoso.com/"}, options); !utils.ResponseErrorWasStatusCode(err, http.StatusBadRequest) {
+		t.Errorf("expected the validation of a domain which isn't hosted to fail, got: %v", err)
+	}
+	s.HostDidConfiguration("https://www.contoso.com")
+	if _, err := client.Action(ctx, http.MethodPost, url+"/validateWellKnownDidConfiguration", "v1.0", map[string]interface{}{"domainUrl": "https://www.contoso.com/"}, options); err != nil {
+		t.Errorf("validating the DID configuration: %v", err)
+	}
+
+	if _, err := client.Action(ctx, http.MethodPost, url+"/didInfo/signingKeys/rotate", "v1.0", nil, options); err != nil {
+		t.Fatalf("rotating the signing key: %v", err)
+	}
+	body, err = client.Action(ctx, http.MethodPost, url+"/generateDidDocument", "v1.0", map[string]interface{}{"domainUrl": "https://www.contoso.com/"}, options)
+	if err != nil {
+		t.Fatalf("generating the DID document: %v", err)
+	}
+	if methods := body.(map[string]interface{})["verificationMethod"].([]interface{}); len(methods) != 2 {
+		t.Errorf("expected 2 verification methods after the rotation, got %d", len(methods))
+	}
+
+	if err := client.Delete(ctx, url, "v1.0", options); err != nil {
+		t.Fatalf("deleting authority: %v", err)
+	}
+	if _, err := client.Read(ctx, url, "v1.0", options); !utils.ResponseErrorWasNotFound(err) {
+		t.Errorf("expected the authority to be deleted, got: %v", err)
+	}
+}
+
+func TestFakeServer_Credentials(t *testing.T) {
+	s, client := newFakeServerTestClient(t)
+	ctx := context.Background()
+	options := clients.DefaultRequestOptions()
+
+	body, err := client.Create(ctx, "verifiableCredentials/authorities", "v1.0", map[string]interface{}{"name": "Contoso"}, options)
+	if err != nil {
+		t.Fatalf("creating authority: %v", err)
+	}
+	authorityId := body.(map[string]interface{})["id"].(string)
+	contractsUrl := fmt.Sprintf("verifiableCredentials/authorities/%s/contracts", authorityId)
+	body, err = client.Create(ctx, contractsUrl, "v1.0", map[string]interface{}{"name": "Employee"}, options)
+	if err != nil {
+		t.Fatalf("creating contract: %v", err)
+	}
+	contractId := body.(map[string]interface{})["id"].(string)
+	if _, err := client.Create(ctx, contractsUrl, "v1.0", map[string]interface{}{"name": "Employee"}, options); !utils.ResponseErrorWasStatusCode(err, http.StatusConflict) {
+		t.Errorf("expected a conflict for a duplicated contract name, got: %v", err)
+	}
+
+	credentialId, err := s.IssueCredential(authorityId, contractId, "alice@contoso.com")
+	if err != nil {
+		t.Fatalf("issuing credential: %v", err)
+	}
+	credentialsUrl := fmt.Sprintf("%s/%s/credentials", contractsUrl, contractId)
+	options.QueryParameters["filter"] = "indexclaimhash eq " + utils.IndexClaimHash(contractId, "alice@contoso.com")
+	body, err = client.Read(ctx, credentialsUrl, "v1.0", options)
+	if err != nil {
+		t.Fatalf("searching credentials: %v", err)
+	}
+	if credentials := body.(map[string]interface{})["value"].([]interface{}); len(credentials) != 1 {
+		t.Fatalf("expected 1 credential, got %d", len(credentials))
+	}
+
+	if _, err := client.Action(ctx, http.MethodPost, fmt.Sprintf("%s/%s/revoke", credentialsUrl, credentialId), "v1.0", nil, clients.DefaultRequestOptions()); err != nil {
+		t.Fatalf("revoking credential: %v", err)
+	}
+	body, _ = client.Read(ctx, credentialsUrl, "v1.0", options)
+	if status := body.(map[string]interface{})["value"].([]interface{})[0].(map[string]interface{})["status"]; status != "revoked" {
+		t.Errorf("expected the credential to be revoked, got %q", status)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.IssueCredential(authorityId, contractId, "alice@contoso.com"); err != nil {
+			t.Fatalf("issuing credential: %v", err)
+		}
+	}
+	s.SetCredentialsPageSize(2)
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1.0/%s?%s", s.URL, credentialsUrl, url.Values{"filter": {options.QueryParameters["filter"]}}.Encode()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer fake")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("searching credentials: %v", err)
+	}
+	var page map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(page["value"].([]interface{})) != 2 || page["@odata.nextLink"] == nil {
+		t.Errorf("expected a first page of 2 credentials linking the next one, got %v", page)
+	}
+	body, err = client.List(ctx, credentialsUrl, "v1.0", options)
+	if err != nil {
+		t.Fatalf("listing credentials: %v", err)
+	}
+	if credentials := body.(map[string]interface{})["value"].([]interface{}); len(credentials) != 3 {
+		t.Errorf("expected the 3 credentials of the 2 pages, got %d", len(credentials))
+	}
+
+	options.QueryParameters["filter"] = "indexclaimhash eq " + utils.IndexClaimHash(contractId, "bob@contoso.com")
+	if _, err := client.Read(ctx, credentialsUrl, "v1.0", options); !utils.ResponseErrorWasNotFound(err) {
+		t.Errorf("expected the search without match to respond with 404, got: %v", err)
+	}
+}
+
+func TestFakeServer_Faults(t *testing.T) {
+	s, client := newFakeServerTestClient(t)
+	ctx := context.Background()
+	options := clients.DefaultRequestOptions()
+
+	// the throttled request is retried by the pipeline after the Retry-After delay
+	s.InjectFault(Fault{Method: http.MethodGet, Path: "authorities", StatusCode: http.StatusTooManyRequests, Count: 1})
+	if _, err := client.Read(ctx, "verifiableCredentials/authorities", "v1.0", options); err != nil {
+		t.Errorf("expected the throttled request to be retried, got: %v", err)
+	}
+
+	s.InjectFault(Fault{Path: "authorities", StatusCode: http.StatusNotFound, Count: 1})
+	if _, err := client.Read(ctx, "verifiableCredentials/authorities", "v1.0", options); !utils.ResponseErrorWasNotFound(err) {
+		t.Errorf("expected the injected not found, got: %v", err)
+	}
+	if _, err := client.Read(ctx, "verifiableCredentials/authorities", "v1.0", options); err != nil {
+		t.Errorf("expected the fault to be injected once, got: %v", err)
+	}
+
+	s.SetOnboarded(false)
+	if _, err := client.Read(ctx, "verifiableCredentials/authorities", "v1.0", options); !utils.ResponseErrorWasErrorCode(err, "TenantNotOnboarded") {
+		t.Errorf("expected the tenant not to be onboarded, got: %v", err)
+	}
+	if _, err := client.Action(ctx, http.MethodPost, "verifiableCredentials/onboard", "v1.0", nil, options); err != nil {
+		t.Fatalf("onboarding: %v", err)
+	}
+	if _, err := client.Read(ctx, "verifiableCredentials/authorities", "v1.0", options); err != nil {
+		t.Errorf("expected the tenant to be onboarded, got: %v", err)
+	}
+}
+
+func TestFakeServer_ContractETag(t *testing.T) {
+	s, client := newFakeServerTestClient(t)
+	ctx := context.Background()
+	options := clients.DefaultRequestOptions()
+
+	body, err := client.Create(ctx, "verifiableCredentials/authorities", "v1.0", map[string]interface{}{"name": "Contoso"}, options)
+	if err != nil {
+		t.Fatalf("creating authority: %v", err)
+	}
+	authorityId := body.(map[string]interface{})["id"].(string)
+	body, err = client.Create(ctx, fmt.Sprintf("verifiableCredentials/authorities/%s/contracts", authorityId), "v1.0", map[string]interface{}{"name": "Employee"}, options)
+	if err != nil {
+		t.Fatalf("creating contract: %v", err)
+	}
+	contractId := body.(map[string]interface{})["id"].(string)
+	url := fmt.Sprintf("verifiableCredentials/authorities/%s/contracts/%s", authorityId, contractId)
+
+	var rawResponse *http.Response
+	body, err = client.Read(policy.WithCaptureResponse(ctx, &rawResponse), url, "v1.0", options)
+	if err != nil {
+		t.Fatalf("reading contract: %v", err)
+	}
+	etag := utils.ResponseETag(rawResponse, body)
+	if etag == "" {
+		t.Fatal("expected the contract to have an ETag")
+	}
+
+	if err := s.ChangeContract(authorityId, contractId, "name", "Changed"); err != nil {
+		t.Fatal(err)
+	}
+	options.Headers = map[string]string{"If-Match": etag}
+	if _, err := client.Update(ctx, url, "v1.0", map[string]interface{}{"name": "Updated"}, options); !utils.ResponseErrorWasStatusCode(err, http.StatusPreconditionFailed) {
+		t.Errorf("expected the update of a changed contract to fail, got: %v", err)
+	}
+
+	options.Headers = nil
+	if _, err := client.Update(ctx, url, "v1.0", map[string]interface{}{"name": "Updated"}, options); err != nil {
+		t.Errorf("expected the unconditional update to succeed, got: %v", err)
+	}
+}
+
+func TestFakeServer_IssuanceRequest(t *testing.T) {
+	s, client := newFakeServerTestClient(t)
+	ctx := context.Background()
+	options := clients.DefaultRequestOptions()
+
+	body, err := client.Create(ctx, "verifiableCredentials/authorities", "v1.0", map[string]interface{}{
+		"name":            "Contoso",
+		"linkedDomainUrl": "https://www.contoso.com/",
+	}, options)
+	if err != nil {
+		t.Fatalf("creating authority: %v", err)
+	}
+	authority := body.(map[string]interface{})
+	did := authority["didModel"].(map[string]interface{})["did"]
+	body, err = client.Create(ctx, fmt.Sprintf("verifiableCredentials/authorities/%s/contracts", authority["id"]), "v1.0", map[string]interface{}{"name": "Employee"}, options)
+	if err != nil {
+		t.Fatalf("creating contract: %v", err)
+	}
+	manifest := body.(map[string]interface{})["manifestUrl"]
+
+	request := map[string]interface{}{
+		"authority":     did,
+		"manifest":      manifest,
+		"type":          "VerifiedEmployee",
+		"includeQRCode": true,
+		"registration":  map[string]interface{}{"clientName": "Contoso"},
+		"callback":      map[string]interface{}{"url": "https://www.contoso.com/callback", "state": "smoke"},
+	}
+	body, err = client.Action(ctx, http.MethodPost, "verifiableCredentials/createIssuanceRequest", "v1.0", request, options)
+	if err != nil {
+		t.Fatalf("creating the issuance request: %v", err)
+	}
+	response := body.(map[string]interface{})
+	if response["requestId"] == "" || response["url"] == "" || response["expiry"] == nil || response["qrCode"] == nil {
+		t.Errorf("unexpected response %v", response)
+	}
+	if requests := s.IssuanceRequests(); len(requests) != 1 || requests[0]["type"] != "VerifiedEmployee" {
+		t.Errorf("expected the issuance request to be recorded, got %v", requests)
+	}
+
+	request["manifest"] = "https://www.contoso.com/manifest"
+	if _, err := client.Action(ctx, http.MethodPost, "verifiableCredentials/createIssuanceRequest", "v1.0", request, options); !utils.ResponseErrorWasStatusCode(err, http.StatusBadRequest) {
+		t.Errorf("expected the issuance request for an unknown manifest to fail, got: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package acceptance
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+)
+
+// defaultRecordingDir is the directory of the cassettes, relative to the package of the tests.
+const defaultRecordingDir = "testdata/recordings"
+
+// recordingMode returns the mode set by VERIFIEDID_RECORDING_MODE, an unknown mode is reported when the provider is configured.
+func recordingMode() clients.RecordingMode {
+	mode, err := clients.ParseRecordingMode(os.Getenv("VERIFIEDID_RECORDING_MODE"))
+	if err != nil {
+		return clients.RecordingModeLive
+	}
+	return mode
+}
+
+// cassettePath returns the cassette of the test, in VERIFIEDID_RECORDING_DIR or defaultRecordingDir.
+func cassettePath(t *testing.T) string {
+	dir := os.Getenv("VERIFIEDID_RECORDING_DIR")
+	if dir == "" {
+		dir = defaultRecordingDir
+	}
+	return filepath.Join(dir, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+}
+
+// seededRand returns a random generator seeded from the name, so it generates the same values every run.
+func seededRand(name string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	// #nosec G404
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+func randStringFromCharSet(r *rand.Rand, strlen int, charSet string) string {
+	result := make([]byte, strlen)
+	for i := 0; i < strlen; i++ {
+		result[i] = charSet[r.Intn(len(charSet))]
+	}
+	return string(result)
+}
@@ -35,18 +35,50 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// cassettePath is the cassette of the test, used when VERIFIEDID_RECORDING_MODE is `record` or `replay`
+	cassettePath string
+
+	// FakeServer is the fake Admin API the test runs against, when it's built with BuildTestDataWithFakeServer or
+	// VERIFIEDID_FAKE_SERVER is set to `true`
+	FakeServer *FakeServer
 }
 
 // BuildTestData generates some test data for the given resource
 func BuildTestData(t *testing.T, resourceType string, resourceLabel string) TestData {
-	return TestData{
+	td := TestData{
 		RandomInteger: RandTimeInt(),
 		RandomString:  RandStringFromCharSet(5, charSetAlphaNum),
 		ResourceName:  fmt.Sprintf("%s.%s", resourceType, resourceLabel),
 
 		ResourceType:  resourceType,
 		resourceLabel: resourceLabel,
+
+		cassettePath: cassettePath(t),
+	}
+
+	if recordingMode() != clients.RecordingModeLive {
+		// The requests are matched on their body when they are replayed, so the random values must be the same as
+		// when they were recorded.
+		r := seededRand(t.Name())
+		td.RandomInteger = r.Intn(int(1e18))
+		td.RandomString = randStringFromCharSet(r, 5, charSetAlphaNum)
+	}
+
+	if os.Getenv("VERIFIEDID_FAKE_SERVER") == "true" {
+		td.FakeServer = NewFakeServer(t)
 	}
+
+	return td
+}
+
+// BuildTestDataWithFakeServer generates some test data for the given resource, the test runs against a fake server
+func BuildTestDataWithFakeServer(t *testing.T, resourceType string, resourceLabel string) TestData {
+	td := BuildTestData(t, resourceType, resourceLabel)
+	if td.FakeServer == nil {
+		td.FakeServer = NewFakeServer(t)
+	}
+	return td
 }
 
 // RandomIntOfLength is a random 8 to 18 digit integer which is unique to this test case
@@ -120,7 +152,7 @@ func (td TestData) ResourceTest(t *testing.T, testResource TestResource, steps [
 	testCase := resource.TestCase{
 		PreCheck: func() { PreCheck(t) },
 		CheckDestroy: func(s *terraform.State) error {
-			client, err := BuildTestClient()
+			client, err := td.testClient()
 			if err != nil {
 				return fmt.Errorf("building client: %+v", err)
 			}
@@ -152,7 +184,16 @@ func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 
 func (td TestData) providers() map[string]func() (tfprotov6.ProviderServer, error) {
 	return map[string]func() (tfprotov6.ProviderServer, error){
-		"verifiedid": providerserver.NewProtocol6WithError(&provider.VerifiedIDProvider{}),
+		"verifiedid": func() (tfprotov6.ProviderServer, error) {
+			p := &provider.VerifiedIDProvider{
+				CassettePath: td.cassettePath,
+			}
+			if td.FakeServer != nil {
+				p.Endpoint = td.FakeServer.URL
+				p.Credential = td.FakeServer.Credential()
+			}
+			return providerserver.NewProtocol6WithError(p)()
+		},
 	}
 }
 
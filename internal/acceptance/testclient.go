@@ -4,10 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
 	"sync"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
@@ -24,110 +23,141 @@ func BuildTestClient() (*clients.Client, error) {
 	defer clientLock.Unlock()
 
 	if _client == nil {
-		var cloudConfig cloud.Configuration
-		env := os.Getenv("ARM_ENVIRONMENT")
-		switch strings.ToLower(env) {
-		case "public":
-			cloudConfig = cloud.AzurePublic
-		case "usgovernment":
-			cloudConfig = cloud.AzureGovernment
-		case "china":
-			cloudConfig = cloud.AzureChina
-		default:
-			cloudConfig = cloud.AzurePublic
+		client, err := newTestClient(clients.RecordingModeLive, "")
+		if err != nil {
+			return nil, err
 		}
+		_client = client
+	}
 
-		model := provider.VerifiedIDProviderModel{}
+	return _client, nil
+}
 
-		// set the defaults from environment variables
-		if v := os.Getenv("ARM_CLIENT_ID"); v != "" {
-			model.ClientID = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_CLIENT_ID_FILE_PATH"); v != "" {
-			model.ClientIDFilePath = types.StringValue(v)
-		}
+// testClient returns the client used to check the resources of the test. When the requests are recorded or replayed,
+// it shares the cassette of the test with the provider, when the test runs against a fake server it calls the server.
+func (td TestData) testClient() (*clients.Client, error) {
+	if td.FakeServer != nil {
+		client := &clients.Client{}
+		err := client.Build(context.TODO(), &clients.Option{
+			Cred:     td.FakeServer.Credential(),
+			Endpoint: td.FakeServer.URL,
+		})
+		return client, err
+	}
 
-		if v := os.Getenv("ARM_USE_AKS_WORKLOAD_IDENTITY"); v != "" {
-			model.UseAKSWorkloadIdentity = types.BoolValue(v == "true")
-		} else {
-			model.UseAKSWorkloadIdentity = types.BoolValue(false)
-		}
-		if v := os.Getenv("ARM_TENANT_ID"); v != "" {
-			model.TenantID = types.StringValue(v)
-		}
-		if model.UseAKSWorkloadIdentity.ValueBool() && os.Getenv("AZURE_TENANT_ID") != "" {
-			aksTenantID := os.Getenv("AZURE_TENANT_ID")
-			if model.TenantID.ValueString() != "" && model.TenantID.ValueString() != aksTenantID {
-				return nil, fmt.Errorf("invalid `tenant_id` value: mismatch between supplied Tenant ID and that provided by AKS Workload Identity - please remove, ensure they match, or disable use_aks_workload_identity")
-			}
-			model.TenantID = types.StringValue(aksTenantID)
-		}
+	mode := recordingMode()
+	if mode == clients.RecordingModeLive {
+		return BuildTestClient()
+	}
+	return newTestClient(mode, td.cassettePath)
+}
 
-		if v := os.Getenv("ARM_CLIENT_CERTIFICATE"); v != "" {
-			model.ClientCertificate = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_CLIENT_CERTIFICATE_PATH"); v != "" {
-			model.ClientCertificatePath = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_CLIENT_CERTIFICATE_PASSWORD"); v != "" {
-			model.ClientCertificatePassword = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_CLIENT_SECRET"); v != "" {
-			model.ClientSecret = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_CLIENT_SECRET_FILE_PATH"); v != "" {
-			model.ClientSecretFilePath = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_OIDC_REQUEST_TOKEN"); v != "" {
-			model.OIDCRequestToken = types.StringValue(v)
-		} else if v := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN"); v != "" {
-			model.OIDCRequestToken = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_OIDC_REQUEST_URL"); v != "" {
-			model.OIDCRequestURL = types.StringValue(v)
-		} else if v := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"); v != "" {
-			model.OIDCRequestURL = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_OIDC_TOKEN"); v != "" {
-			model.OIDCToken = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_OIDC_TOKEN_FILE_PATH"); v != "" {
-			model.OIDCTokenFilePath = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_OIDC_AZURE_SERVICE_CONNECTION_ID"); v != "" {
-			model.OIDCAzureServiceConnectionID = types.StringValue(v)
-		}
-		if v := os.Getenv("ARM_USE_OIDC"); v != "" {
-			model.UseOIDC = types.BoolValue(v == "true")
-		} else {
-			model.UseOIDC = types.BoolValue(false)
-		}
-		if v := os.Getenv("ARM_USE_CLI"); v != "" {
-			model.UseCLI = types.BoolValue(v == "true")
-		} else {
-			model.UseCLI = types.BoolValue(true)
-		}
+func newTestClient(mode clients.RecordingMode, cassettePath string) (*clients.Client, error) {
+	cloudConfig, err := clients.CloudConfiguration(os.Getenv("ARM_ENVIRONMENT"))
+	if err != nil {
+		return nil, err
+	}
 
-		option := azidentity.DefaultAzureCredentialOptions{
-			TenantID: model.TenantID.ValueString(),
-		}
-		cred, err := provider.BuildChainedTokenCredential(model, option)
-		if err != nil {
-			return nil, fmt.Errorf("failed to obtain a credential: %v", err)
-		}
+	model := provider.VerifiedIDProviderModel{}
 
-		copt := &clients.Option{
-			Cred:     cred,
-			CloudCfg: cloudConfig,
-			TenantId: os.Getenv("ARM_TENANT_ID"),
-		}
+	// set the defaults from environment variables
+	if v := os.Getenv("ARM_CLIENT_ID"); v != "" {
+		model.ClientID = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_CLIENT_ID_FILE_PATH"); v != "" {
+		model.ClientIDFilePath = types.StringValue(v)
+	}
 
-		client := &clients.Client{}
-		if err := client.Build(context.TODO(), copt); err != nil {
-			return nil, err
+	if v := os.Getenv("ARM_USE_AKS_WORKLOAD_IDENTITY"); v != "" {
+		model.UseAKSWorkloadIdentity = types.BoolValue(v == "true")
+	} else {
+		model.UseAKSWorkloadIdentity = types.BoolValue(false)
+	}
+	if v := os.Getenv("ARM_TENANT_ID"); v != "" {
+		model.TenantID = types.StringValue(v)
+	}
+	if model.UseAKSWorkloadIdentity.ValueBool() && os.Getenv("AZURE_TENANT_ID") != "" {
+		aksTenantID := os.Getenv("AZURE_TENANT_ID")
+		if model.TenantID.ValueString() != "" && model.TenantID.ValueString() != aksTenantID {
+			return nil, fmt.Errorf("invalid `tenant_id` value: mismatch between supplied Tenant ID and that provided by AKS Workload Identity - please remove, ensure they match, or disable use_aks_workload_identity")
 		}
-		_client = client
+		model.TenantID = types.StringValue(aksTenantID)
 	}
 
-	return _client, nil
+	if v := os.Getenv("ARM_CLIENT_CERTIFICATE"); v != "" {
+		model.ClientCertificate = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_CLIENT_CERTIFICATE_PATH"); v != "" {
+		model.ClientCertificatePath = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_CLIENT_CERTIFICATE_PASSWORD"); v != "" {
+		model.ClientCertificatePassword = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_CLIENT_SECRET"); v != "" {
+		model.ClientSecret = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_CLIENT_SECRET_FILE_PATH"); v != "" {
+		model.ClientSecretFilePath = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_OIDC_REQUEST_TOKEN"); v != "" {
+		model.OIDCRequestToken = types.StringValue(v)
+	} else if v := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN"); v != "" {
+		model.OIDCRequestToken = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_OIDC_REQUEST_URL"); v != "" {
+		model.OIDCRequestURL = types.StringValue(v)
+	} else if v := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"); v != "" {
+		model.OIDCRequestURL = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_OIDC_TOKEN"); v != "" {
+		model.OIDCToken = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_OIDC_TOKEN_FILE_PATH"); v != "" {
+		model.OIDCTokenFilePath = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_OIDC_AZURE_SERVICE_CONNECTION_ID"); v != "" {
+		model.OIDCAzureServiceConnectionID = types.StringValue(v)
+	}
+	if v := os.Getenv("ARM_USE_OIDC"); v != "" {
+		model.UseOIDC = types.BoolValue(v == "true")
+	} else {
+		model.UseOIDC = types.BoolValue(false)
+	}
+	if v := os.Getenv("ARM_USE_CLI"); v != "" {
+		model.UseCLI = types.BoolValue(v == "true")
+	} else {
+		model.UseCLI = types.BoolValue(true)
+	}
+
+	option := azidentity.DefaultAzureCredentialOptions{
+		ClientOptions: azcore.ClientOptions{
+			Cloud: cloudConfig,
+		},
+		TenantID: model.TenantID.ValueString(),
+	}
+	cred, err := provider.BuildChainedTokenCredential(model, option)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain a credential: %v", err)
+	}
+
+	copt := &clients.Option{
+		Cred:       cred,
+		CloudCfg:   cloudConfig,
+		TenantId:   os.Getenv("ARM_TENANT_ID"),
+		Endpoint:   os.Getenv("ARM_VERIFIEDID_ENDPOINT"),
+		TokenScope: os.Getenv("ARM_VERIFIEDID_TOKEN_SCOPE"),
+
+		RequestServiceTokenScope: os.Getenv("ARM_VERIFIEDID_REQUEST_SERVICE_TOKEN_SCOPE"),
+		GraphEndpoint:            os.Getenv("ARM_VERIFIEDID_GRAPH_ENDPOINT"),
+		GraphTokenScope:          os.Getenv("ARM_VERIFIEDID_GRAPH_TOKEN_SCOPE"),
+
+		RecordingMode: mode,
+		CassettePath:  cassettePath,
+	}
+
+	client := &clients.Client{}
+	if err := client.Build(context.TODO(), copt); err != nil {
+		return nil, err
+	}
+	return client, nil
 }
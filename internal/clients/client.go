@@ -3,17 +3,32 @@ package clients
 import (
 	"context"
 	"log"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	azlog "github.com/Azure/azure-sdk-for-go/sdk/azcore/log"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // VerifiedIDClient is a type alias for MSGraphClient for compatibility
 type VerifiedIDClient = MSGraphClient
 
+const (
+	// TargetAPIVerifiedID, TargetAPIGraph and TargetAPIRequestService are the APIs the generic resources and data
+	// sources can target.
+	TargetAPIVerifiedID     = "verifiedid"
+	TargetAPIGraph          = "graph"
+	TargetAPIRequestService = "request_service"
+)
+
+// TargetAPIs are the supported values of the `target_api` attribute.
+var TargetAPIs = []string{TargetAPIVerifiedID, TargetAPIGraph, TargetAPIRequestService}
+
 type Client struct {
 	// StopContext is used for propagating control from Terraform Core (e.g. Ctrl/Cmd+C)
 	StopContext context.Context
@@ -21,17 +36,55 @@ type Client struct {
 	MSGraphClient *MSGraphClient
 	// VerifiedIDClient is an alias to MSGraphClient for compatibility
 	VerifiedIDClient *MSGraphClient
+	// GraphClient calls Microsoft Graph, for the app registrations, service principals and directory objects a
+	// Verified ID setup needs.
+	GraphClient *MSGraphClient
+	// RequestServiceClient calls the Request Service API, which creates the issuance and presentation requests. It
+	// shares the host of the Verified ID Admin API, with tokens for another scope.
+	RequestServiceClient *MSGraphClient
 
 	Option *Option
 }
 
 type Option struct {
-	Cred                        azcore.TokenCredential
+	Cred azcore.TokenCredential
+	// OtherAPIsCred is the credential of the Microsoft Graph and Request Service clients, Cred is used when it is nil.
+	// It is set when Cred has an access token minted for the Admin API only.
+	OtherAPIsCred               azcore.TokenCredential
 	ApplicationUserAgent        string
 	DisableCorrelationRequestID bool
 	CloudCfg                    cloud.Configuration
 	CustomCorrelationRequestID  string
 	TenantId                    string
+	// Endpoint is the host of the Verified ID Admin API, DefaultEndpoint is used when it is empty.
+	Endpoint string
+	// TokenScope is the scope of the access tokens, DefaultTokenScope is used when it is empty.
+	TokenScope string
+	// RequestServiceTokenScope is the scope of the access tokens used to call the Request Service API,
+	// DefaultRequestServiceTokenScope is used when it is empty.
+	RequestServiceTokenScope string
+	// GraphEndpoint is the host of Microsoft Graph, DefaultGraphEndpoint is used when it is empty.
+	GraphEndpoint string
+	// GraphTokenScope is the scope of the access tokens used to call Microsoft Graph, the `.default` scope of
+	// DefaultGraphEndpoint is used when it is empty.
+	GraphTokenScope string
+	// RecordingMode determines whether the requests are recorded to, or replayed from, the cassette at CassettePath.
+	RecordingMode RecordingMode
+	CassettePath  string
+	// MaxConcurrentRequests and RequestsPerSecond limit the requests sent by the provider, 0 means no limit.
+	MaxConcurrentRequests int
+	RequestsPerSecond     float64
+	// DefaultRetry is the retry block used by the resources and data sources which don't have one.
+	DefaultRetry retry.Value
+	// TrafficLogFile is the HTTP Archive the requests and responses are written to, none is written when it is empty.
+	TrafficLogFile string
+	// Redaction are the rules applied to the traffic log, the traffic log file and the cassettes, in addition to the
+	// default ones.
+	Redaction RedactionRules
+	// ReadCacheTTL is how long the responses of the reads are cached, they aren't when it is 0.
+	ReadCacheTTL time.Duration
+	// TracerProvider records a span for each request sent by the provider, none is recorded when it is nil.
+	TracerProvider trace.TracerProvider
 }
 
 func (client *Client) Build(ctx context.Context, o *Option) error {
@@ -51,8 +104,35 @@ func (client *Client) Build(ctx context.Context, o *Option) error {
 		}
 		perCallPolicies = append(perCallPolicies, withCorrelationRequestID(id))
 	}
-	perRetryPolicies := make([]policy.Policy, 0)
-	perRetryPolicies = append(perRetryPolicies, NewLiveTrafficLogPolicy())
+	// The retry policy replaces the azcore one, which is disabled below.
+	perCallPolicies = append(perCallPolicies, NewRetryPolicy(nil))
+	var tracingPolicy policy.Policy
+	if o.TracerProvider != nil {
+		tracingPolicy = NewTracingPolicy(o.TracerProvider)
+	}
+	liveTrafficLogPolicy, err := NewLiveTrafficLogPolicy(o.TrafficLogFile, o.Redaction)
+	if err != nil {
+		return err
+	}
+	throttlingPolicies := make(map[string]policy.Policy)
+	perRetryPolicies := func(endpoint string) []policy.Policy {
+		policies := make([]policy.Policy, 0)
+		// The tracing policy comes first so the spans include the time the requests are held by the throttling policy.
+		if tracingPolicy != nil {
+			policies = append(policies, tracingPolicy)
+		}
+		// The throttling policy is shared by every request sent to a host, it runs for each attempt so the retries are
+		// limited too. Each host throttles on its own, so each one gets its own policy, and the APIs sharing a host,
+		// such as the Admin API and the Request Service API, share it.
+		host := strings.ToLower(strings.TrimSuffix(endpoint, "/"))
+		throttlingPolicy, ok := throttlingPolicies[host]
+		if !ok {
+			throttlingPolicy = NewThrottlingPolicy(o.MaxConcurrentRequests, o.RequestsPerSecond)
+			throttlingPolicies[host] = throttlingPolicy
+		}
+		policies = append(policies, throttlingPolicy)
+		return append(policies, liveTrafficLogPolicy)
+	}
 
 	allowedHeaders := []string{
 		"Access-Control-Allow-Methods",
@@ -91,22 +171,107 @@ func (client *Client) Build(ctx context.Context, o *Option) error {
 		"$format",
 	}
 
-	msgraphClient, err := NewMSGraphClient(o.Cred, &policy.ClientOptions{
-		Logging: policy.LogOptions{
-			IncludeBody:        false,
-			AllowedHeaders:     allowedHeaders,
-			AllowedQueryParams: allowedQueryParams,
-		},
-		PerCallPolicies:  perCallPolicies,
-		PerRetryPolicies: perRetryPolicies,
-	})
+	endpoint := o.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	tokenScope := o.TokenScope
+	if tokenScope == "" {
+		tokenScope = DefaultTokenScope
+	}
+	requestServiceTokenScope := o.RequestServiceTokenScope
+	if requestServiceTokenScope == "" {
+		requestServiceTokenScope = DefaultRequestServiceTokenScope
+	}
+	graphEndpoint := o.GraphEndpoint
+	if graphEndpoint == "" {
+		graphEndpoint = DefaultGraphEndpoint
+	}
+	graphTokenScope := o.GraphTokenScope
+	if graphTokenScope == "" {
+		graphTokenScope = DefaultGraphEndpoint + "/.default"
+	}
+
+	cred := o.Cred
+	otherAPIsCred := o.OtherAPIsCred
+	if otherAPIsCred == nil {
+		otherAPIsCred = cred
+	}
+	var transport policy.Transporter
+	if o.RecordingMode != "" && o.RecordingMode != RecordingModeLive {
+		recordingTransport, err := NewRecordingTransport(o.RecordingMode, o.CassettePath, http.DefaultClient, o.Redaction)
+		if err != nil {
+			return err
+		}
+		transport = recordingTransport
+		if o.RecordingMode == RecordingModeReplay {
+			cred = replayCredential{}
+			otherAPIsCred = replayCredential{}
+		}
+	}
+
+	clientOptions := func(endpoint string) *policy.ClientOptions {
+		return &policy.ClientOptions{
+			Logging: policy.LogOptions{
+				IncludeBody:        false,
+				AllowedHeaders:     allowedHeaders,
+				AllowedQueryParams: allowedQueryParams,
+			},
+			Retry: policy.RetryOptions{
+				MaxRetries: -1,
+			},
+			Transport:        transport,
+			PerCallPolicies:  perCallPolicies,
+			PerRetryPolicies: perRetryPolicies(endpoint),
+			// An http endpoint is only configured on purpose, for example to target a local stand-in server.
+			InsecureAllowCredentialWithHTTP: strings.HasPrefix(strings.ToLower(endpoint), "http://"),
+		}
+	}
+
+	msgraphClient, err := NewMSGraphClient(endpoint, []string{tokenScope}, cred, clientOptions(endpoint))
 	if err != nil {
 		return err
 	}
 
+	msgraphClient.defaultRetry = o.DefaultRetry
+	msgraphClient.cache = NewReadCache(o.ReadCacheTTL)
+
 	client.MSGraphClient = msgraphClient
 	// Set VerifiedIDClient as an alias for backward compatibility
 	client.VerifiedIDClient = msgraphClient
 
+	graphClient, err := NewMSGraphClient(graphEndpoint, []string{graphTokenScope}, otherAPIsCred, clientOptions(graphEndpoint))
+	if err != nil {
+		return err
+	}
+
+	graphClient.defaultRetry = o.DefaultRetry
+	graphClient.cache = NewReadCache(o.ReadCacheTTL)
+
+	client.GraphClient = graphClient
+
+	requestServiceClient, err := NewMSGraphClient(endpoint, []string{requestServiceTokenScope}, otherAPIsCred, clientOptions(endpoint))
+	if err != nil {
+		return err
+	}
+
+	requestServiceClient.defaultRetry = o.DefaultRetry
+	requestServiceClient.cache = NewReadCache(o.ReadCacheTTL)
+
+	client.RequestServiceClient = requestServiceClient
+
 	return nil
 }
+
+// API returns the client of the API a resource targets, one of TargetAPIs. The Verified ID Admin API is targeted by
+// default.
+func (client *Client) API(targetAPI string) *MSGraphClient {
+	switch targetAPI {
+	case TargetAPIGraph:
+		return client.GraphClient
+	case TargetAPIRequestService:
+		return client.RequestServiceClient
+	default:
+		return client.VerifiedIDClient
+	}
+}
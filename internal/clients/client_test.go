@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_API(t *testing.T) {
+	client := &Client{}
+	if err := client.Build(context.Background(), &Option{Cred: replayCredential{}}); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"":                      DefaultEndpoint,
+		TargetAPIVerifiedID:     DefaultEndpoint,
+		TargetAPIGraph:          DefaultGraphEndpoint,
+		TargetAPIRequestService: DefaultEndpoint,
+	}
+	for targetAPI, want := range cases {
+		if got := client.API(targetAPI).GraphBaseUrl(); got != want {
+			t.Errorf("%q: expected host %q, got %q", targetAPI, want, got)
+		}
+	}
+	if client.API(TargetAPIRequestService) == client.VerifiedIDClient {
+		t.Error("expected the Request Service API to have its own client")
+	}
+}
+
+func TestClient_ThrottlingSharedByHost(t *testing.T) {
+	var inFlight, maxInFlight int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{}
+	if err := client.Build(context.Background(), &Option{Cred: replayCredential{}, Endpoint: server.URL, MaxConcurrentRequests: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for _, api := range []*MSGraphClient{client.VerifiedIDClient, client.RequestServiceClient} {
+		wg.Add(1)
+		go func(api *MSGraphClient) {
+			defer wg.Done()
+			if _, err := api.Read(context.Background(), "verifiableCredentials/authorities", "v1.0", RequestOptions{}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(api)
+	}
+	time.Sleep(200 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if maxInFlight != 1 {
+		t.Errorf("expected the APIs sharing a host to share the concurrency limit, got %d requests in flight", maxInFlight)
+	}
+}
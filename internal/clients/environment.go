@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+const (
+	// DefaultEndpoint is the host of the Verified ID Admin API.
+	DefaultEndpoint = "https://verifiedid.did.msidentity.com"
+	// DefaultTokenScope is the scope of the access tokens used to call the Verified ID Admin API.
+	DefaultTokenScope = "6a8b4b39-c021-437c-b060-5a14a3fd65f3/.default"
+	// DefaultRequestServiceTokenScope is the scope of the access tokens used to call the Request Service API, which
+	// creates the issuance and presentation requests. It's served by the host of the Verified ID Admin API.
+	DefaultRequestServiceTokenScope = "3db474b9-6a0c-4840-96ac-1fceb342124f/.default"
+	// DefaultGraphEndpoint is the host of Microsoft Graph in the public cloud.
+	DefaultGraphEndpoint = "https://graph.microsoft.com"
+	// DefaultEnvironment is the cloud environment used when none is configured.
+	DefaultEnvironment = "public"
+)
+
+// Environments are the supported values of the provider `environment` attribute.
+var Environments = []string{"public", "usgovernment", "china"}
+
+// CloudConfiguration returns the cloud configuration, which determines the authority host of the credentials, of an environment.
+func CloudConfiguration(environment string) (cloud.Configuration, error) {
+	switch strings.ToLower(environment) {
+	case "", "public":
+		return cloud.AzurePublic, nil
+	case "usgovernment":
+		return cloud.AzureGovernment, nil
+	case "china":
+		return cloud.AzureChina, nil
+	default:
+		return cloud.Configuration{}, fmt.Errorf("unknown environment %q, supported values are: %s", environment, strings.Join(Environments, ", "))
+	}
+}
+
+// GraphEndpoint returns the host of Microsoft Graph in an environment.
+func GraphEndpoint(environment string) string {
+	switch strings.ToLower(environment) {
+	case "usgovernment":
+		return "https://graph.microsoft.us"
+	case "china":
+		return "https://microsoftgraph.chinacloudapi.cn"
+	default:
+		return DefaultGraphEndpoint
+	}
+}
+
+// GraphTokenScope returns the scope of the access tokens used to call Microsoft Graph in an environment.
+func GraphTokenScope(environment string) string {
+	return GraphEndpoint(environment) + "/.default"
+}
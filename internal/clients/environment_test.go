@@ -0,0 +1,48 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+func TestCloudConfiguration(t *testing.T) {
+	cases := []struct {
+		environment string
+		want        string
+		wantError   bool
+	}{
+		{environment: "", want: cloud.AzurePublic.ActiveDirectoryAuthorityHost},
+		{environment: "public", want: cloud.AzurePublic.ActiveDirectoryAuthorityHost},
+		{environment: "USGovernment", want: cloud.AzureGovernment.ActiveDirectoryAuthorityHost},
+		{environment: "china", want: cloud.AzureChina.ActiveDirectoryAuthorityHost},
+		{environment: "germany", wantError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.environment, func(t *testing.T) {
+			cfg, err := CloudConfiguration(tc.environment)
+			if (err != nil) != tc.wantError {
+				t.Fatalf("expected error: %v, got: %v", tc.wantError, err)
+			}
+			if cfg.ActiveDirectoryAuthorityHost != tc.want {
+				t.Errorf("expected authority host %q, got %q", tc.want, cfg.ActiveDirectoryAuthorityHost)
+			}
+		})
+	}
+}
+
+func TestGraphTokenScope(t *testing.T) {
+	cases := map[string]string{
+		"":             "https://graph.microsoft.com/.default",
+		"public":       "https://graph.microsoft.com/.default",
+		"USGovernment": "https://graph.microsoft.us/.default",
+		"china":        "https://microsoftgraph.chinacloudapi.cn/.default",
+	}
+
+	for environment, want := range cases {
+		if got := GraphTokenScope(environment); got != want {
+			t.Errorf("%q: expected scope %q, got %q", environment, want, got)
+		}
+	}
+}
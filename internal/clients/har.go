@@ -0,0 +1,332 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mjendza/terraform-provider-verifiedid/version"
+)
+
+// harVersion is the version of the HTTP Archive format, see http://www.softwareishard.com/blog/har-12-spec/.
+const harVersion = "1.2"
+
+// har is an HTTP Archive file. The entries are encoded once when they're added and written in batches, at most
+// harFlushInterval after they're added and when the provider stops. The file is replaced by a new one which includes
+// them, so it's well-formed even if the provider is killed while it's written.
+type har struct {
+	path string
+
+	lock    sync.Mutex
+	entries []json.RawMessage
+	// flushTimer is the scheduled write of the entries added since the last one, nil when there's none.
+	flushTimer *time.Timer
+
+	// writeLock orders the writes, so an archive never replaces one with more entries.
+	writeLock sync.Mutex
+}
+
+// harDocument is the content of an HTTP Archive file.
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string            `json:"version"`
+	Creator harCreator        `json:"creator"`
+	Pages   []interface{}     `json:"pages"`
+	Entries []json.RawMessage `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	// Error is the error of a request which got no response, custom fields start with an underscore.
+	Error string `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	Url         string         `json:"url"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// harTimings are in milliseconds. The time to send the request can't be told apart from the time waiting for the
+// response in a policy, so it's included in wait.
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+var (
+	// hars are shared by path, as the provider builds a new client every time it's configured.
+	hars     = make(map[string]*har)
+	harsLock = &sync.Mutex{}
+)
+
+// harFlushInterval is the longest time an entry waits before it's written to the file.
+const harFlushInterval = time.Second
+
+// openHAR returns the HTTP Archive written to path. The entries of the file written by the previous processes are
+// kept, as Terraform runs a new provider process for each phase of a run.
+func openHAR(path string) (*har, error) {
+	harsLock.Lock()
+	defer harsLock.Unlock()
+
+	if h, ok := hars[path]; ok {
+		return h, nil
+	}
+
+	h := &har{
+		path:    path,
+		entries: make([]json.RawMessage, 0),
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var existing harDocument
+		if err := json.Unmarshal(data, &existing); err != nil {
+			return nil, fmt.Errorf("reading traffic log file %q: it isn't an HTTP Archive, remove it to start a new one: %v", path, err)
+		}
+		h.entries = append(h.entries, existing.Log.Entries...)
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("reading traffic log file %q: %v", path, err)
+	}
+	if err := h.flush(); err != nil {
+		return nil, fmt.Errorf("writing traffic log file %q: %v", path, err)
+	}
+	hars[path] = h
+	return h, nil
+}
+
+// FlushTrafficLogs writes the entries of the traffic log files which haven't been written yet, it's called when the
+// provider stops.
+func FlushTrafficLogs() {
+	harsLock.Lock()
+	defer harsLock.Unlock()
+
+	for path, h := range hars {
+		if err := h.flush(); err != nil {
+			log.Printf("[ERROR] Failed to write the traffic log file %q: %v", path, err)
+		}
+	}
+}
+
+// add appends the entry to the archive, it's written to the file with the other entries added within
+// harFlushInterval.
+func (h *har) add(entry harEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.entries = append(h.entries, data)
+	if h.flushTimer == nil {
+		h.flushTimer = time.AfterFunc(harFlushInterval, func() {
+			if err := h.flush(); err != nil {
+				log.Printf("[ERROR] Failed to write the traffic log file %q: %v", h.path, err)
+			}
+		})
+	}
+	return nil
+}
+
+// flush writes the file with the entries added so far.
+func (h *har) flush() error {
+	h.writeLock.Lock()
+	defer h.writeLock.Unlock()
+
+	h.lock.Lock()
+	if h.flushTimer != nil {
+		h.flushTimer.Stop()
+		h.flushTimer = nil
+	}
+	entries := h.entries[:len(h.entries):len(h.entries)]
+	h.lock.Unlock()
+
+	return h.write(entries)
+}
+
+// write replaces the file by an archive of the entries. The archive is written to a temporary file which is renamed,
+// so the file is never truncated or partially written.
+func (h *har) write(entries []json.RawMessage) error {
+	data, err := json.MarshalIndent(harDocument{
+		Log: harLog{
+			Version: harVersion,
+			Creator: harCreator{
+				Name:    "terraform-provider-verifiedid",
+				Version: version.ProviderVersion,
+			},
+			Pages:   make([]interface{}, 0),
+			Entries: entries,
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(h.path), filepath.Base(h.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), h.path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+func newHAREntry(started time.Time, wait time.Duration, receive time.Duration) harEntry {
+	return harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            milliseconds(wait + receive),
+		Timings: harTimings{
+			Wait:    milliseconds(wait),
+			Receive: milliseconds(receive),
+		},
+	}
+}
+
+func newHARRequest(req *http.Request, headers []harNameValue, body string) harRequest {
+	out := harRequest{
+		Method:      req.Method,
+		Url:         req.URL.String(),
+		HttpVersion: httpVersion(req.Proto),
+		Cookies:     make([]harNameValue, 0),
+		Headers:     headers,
+		QueryString: harQueryString(req.URL.Query()),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	if body != "" {
+		out.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     body,
+		}
+	}
+	return out
+}
+
+func newHARResponse(resp *http.Response, headers []harNameValue, body string) harResponse {
+	return harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HttpVersion: httpVersion(resp.Proto),
+		Cookies:     make([]harNameValue, 0),
+		Headers:     headers,
+		Content: harContent{
+			Size:     len(body),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     body,
+		},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+}
+
+// newHARErrorResponse is the response of a request which failed without one, browsers report those with a 0 status.
+func newHARErrorResponse() harResponse {
+	return harResponse{
+		HttpVersion: httpVersion(""),
+		Cookies:     make([]harNameValue, 0),
+		Headers:     make([]harNameValue, 0),
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+}
+
+func harQueryString(query url.Values) []harNameValue {
+	out := make([]harNameValue, 0)
+	for _, name := range sortedKeys(query) {
+		for _, value := range query[name] {
+			out = append(out, harNameValue{Name: name, Value: value})
+		}
+	}
+	return out
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func httpVersion(proto string) string {
+	if proto == "" {
+		return "HTTP/1.1"
+	}
+	return proto
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,226 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+)
+
+func TestLiveTrafficLogPolicy_HAR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "traffic", "traffic.har")
+	closeHAR(t, path)
+	trafficLogPolicy, err := NewLiveTrafficLogPolicy(path, RedactionRules{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl := runtime.NewPipeline(moduleName, moduleVersion, runtime.PipelineOptions{
+		PerRetry: []policy.Policy{trafficLogPolicy},
+	}, &policy.ClientOptions{
+		Retry: policy.RetryOptions{MaxRetries: -1},
+	})
+
+	req, err := runtime.NewRequest(context.Background(), http.MethodPost, server.URL+"/v1.0/verifiableCredentials/authorities?$top=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Raw().Header.Set("Authorization", "Bearer secret")
+	if err := req.SetBody(streaming.NopCloser(strings.NewReader(`{"name":"test"}`)), "application/json"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pl.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	if err := trafficLogPolicy.(*liveTrafficLogPolicy).har.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var archive harDocument
+	if err := json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("expected a well-formed HTTP Archive: %v", err)
+	}
+	if archive.Log.Version != "1.2" {
+		t.Errorf("expected version 1.2, got %q", archive.Log.Version)
+	}
+	if len(archive.Log.Entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(archive.Log.Entries))
+	}
+
+	var entry harEntry
+	if err := json.Unmarshal(archive.Log.Entries[0], &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Request.Method != http.MethodPost || entry.Request.PostData == nil || entry.Request.PostData.Text != `{"name":"test"}` {
+		t.Errorf("unexpected request %+v", entry.Request)
+	}
+	if len(entry.Request.QueryString) != 1 || entry.Request.QueryString[0] != (harNameValue{Name: "$top", Value: "1"}) {
+		t.Errorf("unexpected query string %+v", entry.Request.QueryString)
+	}
+	if !containsHeader(entry.Request.Headers, harNameValue{Name: "Authorization", Value: redactedValue}) {
+		t.Errorf("expected the authorization header to be redacted, got %+v", entry.Request.Headers)
+	}
+	if entry.Response.Status != http.StatusCreated || entry.Response.Content.Text != `{"id":"1"}` || entry.Response.Content.MimeType != "application/json" {
+		t.Errorf("unexpected response %+v", entry.Response)
+	}
+	if entry.Time < 0 || entry.Timings.Wait < 0 || entry.Timings.Receive < 0 {
+		t.Errorf("unexpected timings %+v", entry.Timings)
+	}
+}
+
+func TestHAR_ConcurrentAdd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traffic.har")
+	closeHAR(t, path)
+	h, err := openHAR(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const count = 50
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := h.add(newHAREntry(time.Now(), 0, 0)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := h.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var archive harDocument
+	if err := json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("expected a well-formed HTTP Archive: %v", err)
+	}
+	if len(archive.Log.Entries) != count {
+		t.Errorf("expected %d entries, got %d", count, len(archive.Log.Entries))
+	}
+
+	files, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected only the archive to be left, got %d files", len(files))
+	}
+}
+
+func TestOpenHAR_KeepsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traffic.har")
+	closeHAR(t, path)
+	for i := 1; i <= 2; i++ {
+		h, err := openHAR(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := h.add(newHAREntry(time.Now(), 0, 0)); err != nil {
+			t.Fatal(err)
+		}
+		if err := h.flush(); err != nil {
+			t.Fatal(err)
+		}
+		// the next phase of the run is served by a new provider process
+		harsLock.Lock()
+		delete(hars, path)
+		harsLock.Unlock()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var archive harDocument
+		if err := json.Unmarshal(data, &archive); err != nil {
+			t.Fatalf("expected a well-formed HTTP Archive: %v", err)
+		}
+		if len(archive.Log.Entries) != i {
+			t.Errorf("expected the %d entries of the processes, got %d", i, len(archive.Log.Entries))
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("not an archive"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openHAR(path); err == nil {
+		t.Error("expected a file which isn't an HTTP Archive not to be replaced")
+	}
+}
+
+func TestHAR_FlushedAfterInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traffic.har")
+	closeHAR(t, path)
+	h, err := openHAR(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.add(newHAREntry(time.Now(), 0, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * harFlushInterval)
+	for {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var archive harDocument
+		if err := json.Unmarshal(data, &archive); err != nil {
+			t.Fatalf("expected a well-formed HTTP Archive: %v", err)
+		}
+		if len(archive.Log.Entries) == 1 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the entry to be written within %s", harFlushInterval)
+		}
+		time.Sleep(harFlushInterval / 10)
+	}
+}
+
+// closeHAR forgets the archive of path once the test completes, before its directory is removed.
+func closeHAR(t *testing.T, path string) {
+	t.Cleanup(func() {
+		harsLock.Lock()
+		defer harsLock.Unlock()
+		if h, ok := hars[path]; ok {
+			_ = h.flush()
+			delete(hars, path)
+		}
+	})
+}
+
+func containsHeader(headers []harNameValue, header harNameValue) bool {
+	for _, h := range headers {
+		if h == header {
+			return true
+		}
+	}
+	return false
+}
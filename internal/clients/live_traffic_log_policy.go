@@ -6,15 +6,16 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 )
 
-const redactedValue = "REDACTED"
-
 type liveTrafficLogPolicy struct {
-	notAllowedHeaders map[string]bool
+	redactor *redactor
+	// har is the HTTP Archive the traffic is written to, in addition to the log, if a traffic log file is configured.
+	har *har
 }
 
 type traffic struct {
@@ -35,12 +36,24 @@ type liveResponse struct {
 	Body       string            `json:"body"`
 }
 
-func NewLiveTrafficLogPolicy() policy.Policy {
-	return &liveTrafficLogPolicy{
-		notAllowedHeaders: map[string]bool{
-			"authorization": true,
-		},
+// NewLiveTrafficLogPolicy returns a policy which logs the traffic at the DEBUG level and, when trafficLogFile isn't
+// empty, writes it to that file as an HTTP Archive. The values matching the redaction rules are left out of both.
+func NewLiveTrafficLogPolicy(trafficLogFile string, rules RedactionRules) (policy.Policy, error) {
+	r, err := newRedactor(rules)
+	if err != nil {
+		return nil, err
+	}
+	p := &liveTrafficLogPolicy{
+		redactor: r,
 	}
+	if trafficLogFile != "" {
+		h, err := openHAR(trafficLogFile)
+		if err != nil {
+			return nil, err
+		}
+		p.har = h
+	}
+	return p, nil
 }
 
 func (p *liveTrafficLogPolicy) Do(req *policy.Request) (*http.Response, error) {
@@ -54,7 +67,9 @@ func (p *liveTrafficLogPolicy) Do(req *policy.Request) (*http.Response, error) {
 	if err := req.RewindBody(); err != nil {
 		return nil, err
 	}
+	started := time.Now()
 	response, err := req.Next() // Make the request
+	wait := time.Since(started)
 	liveResp := liveResponse{}
 	if err == nil {
 		liveResp.Headers = p.header(response.Header)
@@ -63,6 +78,21 @@ func (p *liveTrafficLogPolicy) Do(req *policy.Request) (*http.Response, error) {
 	} else {
 		liveResp.Body = err.Error()
 	}
+	receive := time.Since(started) - wait
+
+	if p.har != nil {
+		entry := newHAREntry(started, wait, receive)
+		entry.Request = newHARRequest(rawRequest, p.harHeaders(rawRequest.Header), liveReq.Body)
+		if err == nil {
+			entry.Response = newHARResponse(response, p.harHeaders(response.Header), liveResp.Body)
+		} else {
+			entry.Response = newHARErrorResponse()
+			entry.Error = err.Error()
+		}
+		if harErr := p.har.add(entry); harErr != nil {
+			log.Printf("[ERROR] Failed to write the traffic log file %q: %v", p.har.path, harErr)
+		}
+	}
 	liveTraffic := traffic{
 		LiveRequest:  liveReq,
 		LiveResponse: liveResp,
@@ -91,7 +121,7 @@ func (p *liveTrafficLogPolicy) requestBodyString(req *policy.Request) string {
 		log.Printf("[ERROR] Failed to rewind request body: %v", err)
 		return ""
 	}
-	return string(body)
+	return p.redactor.redactBody(body)
 }
 
 func (p *liveTrafficLogPolicy) responseBodyString(resp *http.Response) string {
@@ -100,13 +130,27 @@ func (p *liveTrafficLogPolicy) responseBodyString(resp *http.Response) string {
 		log.Printf("[ERROR] Failed to read response body: %v", err)
 		return ""
 	}
-	return string(body)
+	return p.redactor.redactBody(body)
+}
+
+// harHeaders returns the headers as HAR name/value pairs, one per value, with the not allowed headers redacted.
+func (p *liveTrafficLogPolicy) harHeaders(input http.Header) []harNameValue {
+	output := make([]harNameValue, 0, len(input))
+	for _, k := range sortedKeys(input) {
+		for _, v := range input[k] {
+			if p.redactor.isRedactedHeader(k) {
+				v = redactedValue
+			}
+			output = append(output, harNameValue{Name: k, Value: v})
+		}
+	}
+	return output
 }
 
 func (p *liveTrafficLogPolicy) header(input http.Header) map[string]string {
 	output := make(map[string]string)
 	for k, v := range input {
-		if p.notAllowedHeaders[strings.ToLower(k)] {
+		if p.redactor.isRedactedHeader(k) {
 			output[k] = redactedValue
 		} else {
 			output[k] = strings.Join(v, ",")
@@ -0,0 +1,52 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// longRunningOperationPollFrequency is the delay between two polls of a long-running operation, when the service
+// doesn't send a Retry-After header.
+const longRunningOperationPollFrequency = 10 * time.Second
+
+// longRunningOperationHeaders are the headers of a 202 Accepted response pointing to the status of a long-running operation.
+var longRunningOperationHeaders = []string{
+	"Azure-AsyncOperation",
+	"Operation-Location",
+	"Location",
+}
+
+// isLongRunningOperation reports whether the response started a long-running operation which must be polled.
+func isLongRunningOperation(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusAccepted {
+		return false
+	}
+	for _, header := range longRunningOperationHeaders {
+		if resp.Header.Get(header) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// pollUntilDone polls the long-running operation started by the response until it reaches a terminal state, or the
+// context is done, and returns the result of the operation. The error of a failed operation is an *azcore.ResponseError
+// carrying the payload returned by the service.
+func (client *MSGraphClient) pollUntilDone(ctx context.Context, resp *http.Response) (interface{}, error) {
+	poller, err := runtime.NewPoller[interface{}](resp, client.pl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting to poll the long-running operation of %s %s: %w", resp.Request.Method, resp.Request.URL.Path, err)
+	}
+
+	result, err := poller.PollUntilDone(ctx, &runtime.PollUntilDoneOptions{
+		Frequency: longRunningOperationPollFrequency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("polling the long-running operation of %s %s: %w", resp.Request.Method, resp.Request.URL.Path, err)
+	}
+	return result, nil
+}
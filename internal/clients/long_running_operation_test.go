@@ -0,0 +1,103 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *MSGraphClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &MSGraphClient{
+		host: server.URL,
+		pl:   runtime.NewPipeline(moduleName, moduleVersion, runtime.PipelineOptions{}, nil),
+	}
+}
+
+func TestCreate_LongRunningOperationLocation(t *testing.T) {
+	var polls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.Header().Set("Location", "http://"+r.Host+"/operations/1")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/operations/1" && atomic.AddInt32(&polls, 1) == 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		}
+	})
+
+	result, err := client.Create(context.Background(), "items", "v1.0", map[string]interface{}{}, RequestOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("expected 2 polls, got %d", polls)
+	}
+	if m, ok := result.(map[string]interface{}); !ok || m["id"] != "1" {
+		t.Errorf("unexpected result: %v", result)
+	}
+}
+
+func TestUpdate_LongRunningOperationFailed(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			w.Header().Set("Azure-AsyncOperation", "http://"+r.Host+"/operations/1")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"Failed","error":{"code":"ContractInvalid","message":"The contract is invalid."}}`))
+	})
+
+	_, err := client.Update(context.Background(), "items/1", "v1.0", map[string]interface{}{}, RequestOptions{})
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) {
+		t.Fatalf("expected a response error, got: %v", err)
+	}
+	if responseErr.ErrorCode != "ContractInvalid" {
+		t.Errorf("expected error code %q, got %q", "ContractInvalid", responseErr.ErrorCode)
+	}
+}
+
+func TestDelete_LongRunningOperationTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Operation-Location", "http://"+r.Host+"/operations/1")
+		w.Header().Set("Retry-After", "1")
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"Running"}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	if err := client.Delete(ctx, "items/1", "v1.0", RequestOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context deadline to be exceeded, got: %v", err)
+	}
+}
+
+func TestDelete_AcceptedWithoutOperation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	if err := client.Delete(context.Background(), "items/1", "v1.0", RequestOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -3,10 +3,12 @@ package clients
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
 )
 
 const (
@@ -18,29 +20,44 @@ const (
 type MSGraphClient struct {
 	host string
 	pl   runtime.Pipeline
+
+	// defaultRetry is the retry block of the provider, used by the resources which have none.
+	defaultRetry retry.Value
+	// cache holds the responses of the reads, it's nil when the read cache is disabled.
+	cache *ReadCache
 }
 
-func NewMSGraphClient(credential azcore.TokenCredential, opt *policy.ClientOptions) (*MSGraphClient, error) {
+func NewMSGraphClient(host string, scopes []string, credential azcore.TokenCredential, opt *policy.ClientOptions) (*MSGraphClient, error) {
 	pl := runtime.NewPipeline(moduleName, moduleVersion, runtime.PipelineOptions{
 		AllowedHeaders:         nil,
 		AllowedQueryParameters: nil,
 		APIVersion:             runtime.APIVersionOptions{},
 		PerCall:                nil,
 		PerRetry: []policy.Policy{
-			runtime.NewBearerTokenPolicy(credential, []string{"6a8b4b39-c021-437c-b060-5a14a3fd65f3/.default"}, nil),
+			runtime.NewBearerTokenPolicy(credential, scopes, &policy.BearerTokenOptions{
+				InsecureAllowCredentialWithHTTP: opt != nil && opt.InsecureAllowCredentialWithHTTP,
+			}),
 		},
 		Tracing: runtime.TracingOptions{},
 	}, opt)
 	return &MSGraphClient{
-		host: "https://verifiedid.did.msidentity.com",
+		host: strings.TrimSuffix(host, "/"),
 		pl:   pl,
 	}, nil
 }
 
 func (client *MSGraphClient) Read(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error) {
+	cacheKey := readCacheKey(http.MethodGet, url, apiVersion, options)
+	if !readCacheBypassed(ctx) {
+		if responseBody, ok := client.cache.Get(cacheKey); ok {
+			return responseBody, nil
+		}
+	}
+	cacheGeneration := client.cache.Begin()
+	defer client.cache.End(cacheGeneration)
 	// apply per-request retry options via context
 	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
+		ctx = withRetryOptions(ctx, *options.RetryOptions)
 	}
 	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
@@ -71,14 +88,26 @@ func (client *MSGraphClient) Read(ctx context.Context, url string, apiVersion st
 	// if response has nextLink, follow the link and return the final response
 	if responseBodyMap, ok := responseBody.(map[string]interface{}); ok {
 		if nextLink := responseBodyMap["@odata.nextLink"]; nextLink != nil {
-			return client.List(ctx, url, apiVersion, options)
+			responseBody, err = client.List(ctx, url, apiVersion, options)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
+	client.cache.Set(cacheKey, url, cacheGeneration, responseBody)
 	return responseBody, nil
 }
 
 func (client *MSGraphClient) List(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error) {
+	cacheKey := readCacheKey("LIST", url, apiVersion, options)
+	if !readCacheBypassed(ctx) {
+		if responseBody, ok := client.cache.Get(cacheKey); ok {
+			return responseBody, nil
+		}
+	}
+	cacheGeneration := client.cache.Begin()
+	defer client.cache.End(cacheGeneration)
 	pager := runtime.NewPager(runtime.PagingHandler[interface{}]{
 		More: func(current interface{}) bool {
 			if current == nil {
@@ -98,7 +127,7 @@ func (client *MSGraphClient) List(ctx context.Context, url string, apiVersion st
 		},
 		Fetcher: func(ctx context.Context, current *interface{}) (interface{}, error) {
 			if options.RetryOptions != nil {
-				ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
+				ctx = withRetryOptions(ctx, *options.RetryOptions)
 			}
 			var request *policy.Request
 			if current == nil {
@@ -166,17 +195,21 @@ func (client *MSGraphClient) List(ctx context.Context, url string, apiVersion st
 		}
 
 		// if response doesn't follow the paging guideline, return the response as is
+		client.cache.Set(cacheKey, url, cacheGeneration, page)
 		return page, nil
 	}
 
 	out["value"] = value
 
+	client.cache.Set(cacheKey, url, cacheGeneration, out)
 	return out, nil
 }
 
 func (client *MSGraphClient) Create(ctx context.Context, url string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
+	// the cached reads of the entity are stale once it's changed, whether the request succeeds or not
+	defer client.cache.Invalidate(url)
 	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
+		ctx = withRetryOptions(ctx, *options.RetryOptions)
 	}
 	req, err := runtime.NewRequest(ctx, http.MethodPost, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
@@ -202,7 +235,9 @@ func (client *MSGraphClient) Create(ctx context.Context, url string, apiVersion
 		return nil, runtime.NewResponseError(resp)
 	}
 
-	// TODO: Handle long-running operations if needed
+	if isLongRunningOperation(resp) {
+		return client.pollUntilDone(ctx, resp)
+	}
 
 	var responseBody interface{}
 	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
@@ -212,8 +247,9 @@ func (client *MSGraphClient) Create(ctx context.Context, url string, apiVersion
 }
 
 func (client *MSGraphClient) Update(ctx context.Context, url string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
+	defer client.cache.Invalidate(url)
 	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
+		ctx = withRetryOptions(ctx, *options.RetryOptions)
 	}
 	req, err := runtime.NewRequest(ctx, http.MethodPatch, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
@@ -239,7 +275,9 @@ func (client *MSGraphClient) Update(ctx context.Context, url string, apiVersion
 		return nil, runtime.NewResponseError(resp)
 	}
 
-	// TODO: Handle long-running operations if needed
+	if isLongRunningOperation(resp) {
+		return client.pollUntilDone(ctx, resp)
+	}
 
 	var responseBody interface{}
 	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
@@ -249,8 +287,9 @@ func (client *MSGraphClient) Update(ctx context.Context, url string, apiVersion
 }
 
 func (client *MSGraphClient) Delete(ctx context.Context, url string, apiVersion string, options RequestOptions) error {
+	defer client.cache.Invalidate(url)
 	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
+		ctx = withRetryOptions(ctx, *options.RetryOptions)
 	}
 	req, err := runtime.NewRequest(ctx, http.MethodDelete, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
@@ -270,18 +309,24 @@ func (client *MSGraphClient) Delete(ctx context.Context, url string, apiVersion
 		return err
 	}
 
-	// TODO: Handle long-running operations if needed
-
 	if !runtime.HasStatusCode(resp, http.StatusOK, http.StatusAccepted, http.StatusNoContent) {
 		return runtime.NewResponseError(resp)
 	}
+
+	if isLongRunningOperation(resp) {
+		_, err := client.pollUntilDone(ctx, resp)
+		return err
+	}
 	return nil
 }
 
 func (client *MSGraphClient) Action(ctx context.Context, method string, url string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
+	if method != http.MethodGet && method != http.MethodHead {
+		defer client.cache.Invalidate(url)
+	}
 	// apply per-request retry options via context
 	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
+		ctx = withRetryOptions(ctx, *options.RetryOptions)
 	}
 
 	req, err := runtime.NewRequest(ctx, method, runtime.JoinPaths(client.host, apiVersion, url))
@@ -330,6 +375,21 @@ func (client *MSGraphClient) Action(ctx context.Context, method string, url stri
 	return responseBody, nil
 }
 
+// RetryOptions returns the retry options of a request sent during the operation, from the retry block of the resource
+// or, when it has none or its `operations` leave out the operation, from the retry block of the provider.
+// InvalidateReadCache drops the cached responses of the reads of url, of its ancestors and of its descendants, for
+// the requests which change entities at other paths than their own.
+func (client *MSGraphClient) InvalidateReadCache(url string) {
+	client.cache.Invalidate(url)
+}
+
+func (client *MSGraphClient) RetryOptions(rtry retry.Value, operation string) *RetryOptions {
+	if !rtry.AppliesTo(operation) {
+		rtry = client.defaultRetry
+	}
+	return NewRetryOptions(rtry, operation)
+}
+
 func (client *MSGraphClient) GraphBaseUrl() string {
 	return client.host
 }
@@ -29,11 +29,11 @@ var DefaultRetryableReadAfterCreateStatusCodes = []int{
 type RequestOptions struct {
 	Headers         map[string]string
 	QueryParameters map[string]string
-	RetryOptions    *policy.RetryOptions
+	RetryOptions    *RetryOptions
 }
 
-// CombineRetryOptions combines multiple RequestOptions into a single policy.RetryOptions.
-func CombineRetryOptions(opts ...*policy.RetryOptions) *policy.RetryOptions {
+// CombineRetryOptions combines multiple RequestOptions into a single RetryOptions.
+func CombineRetryOptions(opts ...*RetryOptions) *RetryOptions {
 	if len(opts) == 0 {
 		return nil
 	}
@@ -77,6 +77,14 @@ func CombineRetryOptions(opts ...*policy.RetryOptions) *policy.RetryOptions {
 		}
 	}
 
+	// the delays are only deterministic if none of the options wants jitter
+	disableJitter := true
+	for _, opt := range opts {
+		if opt != nil && !opt.DisableJitter {
+			disableJitter = false
+		}
+	}
+
 	shouldRetry := func(resp *http.Response, err error) bool {
 		for _, opt := range opts {
 			if opt == nil || opt.ShouldRetry == nil {
@@ -89,81 +97,111 @@ func CombineRetryOptions(opts ...*policy.RetryOptions) *policy.RetryOptions {
 		return false
 	}
 
-	return &policy.RetryOptions{
-		MaxRetries:    maxRetries,
-		RetryDelay:    retryDelay,
-		MaxRetryDelay: maxRetryDelay,
-		StatusCodes:   statusCodes,
-		ShouldRetry:   shouldRetry,
+	return &RetryOptions{
+		RetryOptions: policy.RetryOptions{
+			MaxRetries:    maxRetries,
+			RetryDelay:    retryDelay,
+			MaxRetryDelay: maxRetryDelay,
+			StatusCodes:   statusCodes,
+			ShouldRetry:   shouldRetry,
+		},
+		DisableJitter: disableJitter,
 	}
 }
 
 // NewRetryOptionsForReadAfterCreate creates a RetryOptions for read-after-create operations.
-func NewRetryOptionsForReadAfterCreate() *policy.RetryOptions {
+func NewRetryOptionsForReadAfterCreate() *RetryOptions {
 	log.Printf("[DEBUG] Using custom retry configuration for read after create")
 	statusCodes := make([]int, 0)
 	statusCodes = append(statusCodes, DefaultRetryableStatusCodes...)
 	// Add default read after create values for the default retry configuration.
 	statusCodes = append(statusCodes, DefaultRetryableReadAfterCreateStatusCodes...)
-	return &policy.RetryOptions{
-		// Set a very high max retries to make sure context deadline is respected.
-		MaxRetries:  math.MaxInt16,
-		StatusCodes: statusCodes,
-		ShouldRetry: func(resp *http.Response, err error) bool {
-			// We need to test for status codes here too. This covers the case that these options are combined with
-			// retry options from NewRetryOptions, because the ShouldRetry function takes precedence over StatusCodes.
-			for _, code := range statusCodes {
-				if resp.StatusCode == code {
-					return true
+	return &RetryOptions{
+		RetryOptions: policy.RetryOptions{
+			// Set a very high max retries to make sure context deadline is respected.
+			MaxRetries:  math.MaxInt16,
+			StatusCodes: statusCodes,
+			ShouldRetry: func(resp *http.Response, err error) bool {
+				// We need to test for status codes here too. This covers the case that these options are combined with
+				// retry options from NewRetryOptions, because the ShouldRetry function takes precedence over StatusCodes.
+				if resp == nil {
+					return false
 				}
-			}
-			return false
+				for _, code := range statusCodes {
+					if resp.StatusCode == code {
+						return true
+					}
+				}
+				return false
+			},
 		},
 	}
 }
 
-// NewRetryOptions creates a RetryOptions based on the provided retry.RetryValue.
-func NewRetryOptions(rtry retry.Value) *policy.RetryOptions {
-	if rtry.IsNull() || rtry.IsUnknown() {
+// NewRetryOptions creates a RetryOptions based on the provided retry.RetryValue, for a request sent during the given
+// operation. It returns nil, the default retries, when the retry isn't set or doesn't apply to the operation.
+func NewRetryOptions(rtry retry.Value, operation string) *RetryOptions {
+	if !rtry.AppliesTo(operation) {
 		return nil
 	}
 
 	log.Printf("[DEBUG] Using custom retry configuration")
-	return &policy.RetryOptions{
-		// Set a very high max retries to make sure context deadline is respected.
-		MaxRetries:  math.MaxInt16,
-		StatusCodes: DefaultRetryableStatusCodes,
-		ShouldRetry: func(resp *http.Response, err error) bool {
-			// We need to test for DefaultRetryableStatusCodes here as using ShouldRetry overrides the use of StatusCodes.
-			for _, code := range DefaultRetryableStatusCodes {
-				if resp.StatusCode == code {
-					return true
+	statusCodes := rtry.GetStatusCodes()
+	if statusCodes == nil {
+		statusCodes = DefaultRetryableStatusCodes
+	}
+
+	// Set a very high max retries to make sure context deadline is respected.
+	var maxRetries int32 = math.MaxInt16
+	if maxAttempts := rtry.GetMaxAttempts(); maxAttempts > 0 {
+		maxRetries = int32(min(maxAttempts-1, math.MaxInt16))
+		if maxRetries == 0 {
+			// a zero MaxRetries means the default number of retries
+			maxRetries = -1
+		}
+	}
+
+	return &RetryOptions{
+		RetryOptions: policy.RetryOptions{
+			MaxRetries:    maxRetries,
+			RetryDelay:    rtry.GetMinDelay(),
+			MaxRetryDelay: rtry.GetMaxDelay(),
+			StatusCodes:   statusCodes,
+			ShouldRetry: func(resp *http.Response, err error) bool {
+				// We need to test for the status codes here as using ShouldRetry overrides the use of StatusCodes.
+				if resp != nil {
+					for _, code := range statusCodes {
+						if resp.StatusCode == code {
+							return true
+						}
+					}
 				}
-			}
 
-			// Get the error message to check against regex patterns,
-			// If use the err.Error() string first, else get the response error from the HTTP response.
-			var errorMsg string
-			if err != nil {
-				errorMsg = err.Error()
-			} else {
-				responseErr := runtime.NewResponseError(resp)
-				if responseErr != nil {
-					errorMsg = responseErr.Error()
+				// Get the error message to check against regex patterns,
+				// If use the err.Error() string first, else get the response error from the HTTP response.
+				var errorMsg string
+				if err != nil {
+					errorMsg = err.Error()
+				} else if resp != nil {
+					responseErr := runtime.NewResponseError(resp)
+					if responseErr != nil {
+						errorMsg = responseErr.Error()
+					}
 				}
-			}
-			// Check if the error message matches any of the retryable error regexps
-			if errorMsg == "" {
-				return false
-			}
-			for _, re := range rtry.GetErrorMessagesRegex() {
-				if re.MatchString(errorMsg) {
-					log.Printf("[DEBUG] Retrying request due to error: %s matches regex %s", errorMsg, re.String())
-					return true
+				// Check if the error message matches any of the retryable error regexps
+				if errorMsg == "" {
+					return false
 				}
-			}
-			return false
+				for _, re := range rtry.GetErrorMessagesRegex() {
+					if re.MatchString(errorMsg) {
+						log.Printf("[DEBUG] Retrying request due to error: %s matches regex %s", errorMsg, re.String())
+						return true
+					}
+				}
+				return false
+			},
 		},
+		DisableJitter: !rtry.GetJitter(),
 	}
 }
 
@@ -0,0 +1,204 @@
+package clients
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReadCache holds the responses of the reads sent by the provider, so the resources and data sources reading the same
+// entity during a plan or an apply share a single request. The entries expire after a short TTL, and any mutating
+// request drops the entries of the paths it overlaps.
+type ReadCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]readCacheEntry
+	// generation is incremented by each invalidation, invalidated holds the generation of the last invalidation of
+	// each path so the reads which started before it aren't cached. running counts the reads in progress by the
+	// generation they started at, the invalidations no running read started before are dropped.
+	generation  uint64
+	invalidated map[string]uint64
+	running     map[uint64]int
+}
+
+type readCacheEntry struct {
+	path    string
+	value   interface{}
+	expires time.Time
+}
+
+// NewReadCache returns a cache keeping the responses for ttl, nil when ttl isn't positive which disables the cache.
+func NewReadCache(ttl time.Duration) *ReadCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &ReadCache{
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     make(map[string]readCacheEntry),
+		invalidated: make(map[string]uint64),
+		running:     make(map[uint64]int),
+	}
+}
+
+type readCacheBypassKey struct{}
+
+// WithoutReadCache returns a context whose reads are always sent to the service, for example to capture the headers
+// of the response. Their responses still refresh the cache.
+func WithoutReadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readCacheBypassKey{}, true)
+}
+
+func readCacheBypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(readCacheBypassKey{}).(bool)
+	return bypassed
+}
+
+// readCacheKey identifies a read by its operation, URL, API version, query parameters and headers.
+func readCacheKey(operation string, path string, apiVersion string, options RequestOptions) string {
+	query := url.Values{}
+	for key, value := range options.QueryParameters {
+		query.Set(key, value)
+	}
+	headers := make([]string, 0, len(options.Headers))
+	for key, value := range options.Headers {
+		headers = append(headers, strings.ToLower(key)+":"+value)
+	}
+	sort.Strings(headers)
+	return strings.Join([]string{operation, apiVersion, normalizeCachePath(path), query.Encode(), strings.Join(headers, "\n")}, " ")
+}
+
+// normalizeCachePath returns the path without its query, surrounding slashes and case, the service being
+// case-insensitive.
+func normalizeCachePath(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	return strings.ToLower(strings.Trim(path, "/"))
+}
+
+// pathsOverlap returns whether a path is the other one or one of its ancestors, segment-wise.
+func pathsOverlap(a string, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a == "" || a == b || strings.HasPrefix(b, a+"/")
+}
+
+// Get returns a copy of the cached response of the read, if it hasn't expired.
+func (c *ReadCache) Get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return copyJSONValue(entry.value), true
+}
+
+// Begin returns the generation of the cache when a read starts, which is passed to Set once the read completes and
+// to End once it's done.
+func (c *ReadCache) Begin() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.running[c.generation]++
+	return c.generation
+}
+
+// End completes a read which started at generation started, whether it succeeded or not.
+func (c *ReadCache) End(started uint64) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.running[started]--; c.running[started] <= 0 {
+		delete(c.running, started)
+	}
+
+	oldest := c.generation
+	for generation := range c.running {
+		oldest = min(oldest, generation)
+	}
+	for path, generation := range c.invalidated {
+		if generation <= oldest {
+			delete(c.invalidated, path)
+		}
+	}
+}
+
+// Set caches a copy of the response of the read of path, unless an overlapping path was invalidated since the read
+// started at generation started, in which case the response may predate the change.
+func (c *ReadCache) Set(key string, path string, started uint64, value interface{}) {
+	if c == nil {
+		return
+	}
+	path = normalizeCachePath(path)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for invalidatedPath, generation := range c.invalidated {
+		if generation > started && pathsOverlap(invalidatedPath, path) {
+			return
+		}
+	}
+	c.entries[key] = readCacheEntry{
+		path:    path,
+		value:   copyJSONValue(value),
+		expires: c.now().Add(c.ttl),
+	}
+}
+
+// Invalidate drops the responses of the reads of path, of its ancestors and of its descendants. The ancestors are
+// dropped because their responses may embed or list the entity.
+func (c *ReadCache) Invalidate(path string) {
+	if c == nil {
+		return
+	}
+	path = normalizeCachePath(path)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generation++
+	if len(c.running) != 0 {
+		c.invalidated[path] = c.generation
+	}
+	for key, entry := range c.entries {
+		if pathsOverlap(entry.path, path) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// copyJSONValue returns a deep copy of a decoded JSON value, so the callers can't modify the cached responses.
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[key] = copyJSONValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = copyJSONValue(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,162 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRead_Cache(t *testing.T) {
+	var reads int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&reads, 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","name":"authority"}`))
+	})
+	client.cache = NewReadCache(time.Minute)
+	ctx := context.Background()
+	read := func(url string, options RequestOptions) map[string]interface{} {
+		result, err := client.Read(ctx, url, "beta", options)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result.(map[string]interface{})
+	}
+
+	first := read("verifiableCredentials/authorities/1", RequestOptions{})
+	first["name"] = "changed"
+	if second := read("/verifiableCredentials/authorities/1/", RequestOptions{}); second["name"] != "authority" {
+		t.Errorf("expected the cached response to be unchanged by the caller, got %v", second)
+	}
+	if reads != 1 {
+		t.Fatalf("expected 1 read, got %d", reads)
+	}
+
+	read("verifiableCredentials/authorities/1", RequestOptions{QueryParameters: map[string]string{"$select": "id"}})
+	read("verifiableCredentials/authorities/1", RequestOptions{Headers: map[string]string{"ConsistencyLevel": "eventual"}})
+	if reads != 3 {
+		t.Errorf("expected the query parameters and headers to be part of the key, got %d reads", reads)
+	}
+
+	if _, err := client.Read(WithoutReadCache(ctx), "verifiableCredentials/authorities/1", "beta", RequestOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reads != 4 {
+		t.Errorf("expected the bypassing read to be sent, got %d reads", reads)
+	}
+
+	if _, err := client.Update(ctx, "verifiableCredentials/authorities/1/contracts/2", "beta", map[string]interface{}{}, RequestOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read("verifiableCredentials/authorities/1", RequestOptions{})
+	if reads != 5 {
+		t.Errorf("expected the update of a child to invalidate the parent, got %d reads", reads)
+	}
+
+	if _, err := client.Update(ctx, "verifiableCredentials/authorities/10", "beta", map[string]interface{}{}, RequestOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read("verifiableCredentials/authorities/1", RequestOptions{})
+	if reads != 5 {
+		t.Errorf("expected the update of a sibling to keep the cache, got %d reads", reads)
+	}
+
+	if _, err := client.Action(ctx, http.MethodPost, "verifiableCredentials/optout", "beta", nil, RequestOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read("verifiableCredentials/authorities/1", RequestOptions{})
+	if reads != 5 {
+		t.Errorf("expected an action at another path to keep the cache, got %d reads", reads)
+	}
+	client.InvalidateReadCache("verifiableCredentials/authorities")
+	read("verifiableCredentials/authorities/1", RequestOptions{})
+	if reads != 6 {
+		t.Errorf("expected the explicit invalidation of the authorities to drop the authority, got %d reads", reads)
+	}
+}
+
+func TestRead_CacheExpires(t *testing.T) {
+	var reads int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&reads, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"value":[]}`))
+	})
+	now := time.Now()
+	client.cache = NewReadCache(30 * time.Second)
+	client.cache.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.List(context.Background(), "verifiableCredentials/authorities", "beta", RequestOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if reads != 1 {
+		t.Fatalf("expected 1 read, got %d", reads)
+	}
+
+	now = now.Add(30 * time.Second)
+	if _, err := client.List(context.Background(), "verifiableCredentials/authorities", "beta", RequestOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reads != 2 {
+		t.Errorf("expected the expired response to be read again, got %d reads", reads)
+	}
+}
+
+func TestNewReadCache_Disabled(t *testing.T) {
+	if NewReadCache(0) != nil {
+		t.Error("expected no cache without a TTL")
+	}
+}
+
+func TestReadCache_SetAfterInvalidate(t *testing.T) {
+	cache := NewReadCache(time.Minute)
+
+	// a read which started before an update and completes after it may return the entity as it was before the update
+	started := cache.Begin()
+	cache.Invalidate("verifiableCredentials/authorities/1/contracts/2")
+	cache.Set("stale", "verifiableCredentials/authorities/1", started, map[string]interface{}{})
+	if _, ok := cache.Get("stale"); ok {
+		t.Error("expected the read which started before the invalidation not to be cached")
+	}
+
+	cache.Set("sibling", "verifiableCredentials/authorities/10", started, map[string]interface{}{})
+	if _, ok := cache.Get("sibling"); !ok {
+		t.Error("expected the read of a sibling to be cached")
+	}
+
+	fresh := cache.Begin()
+	cache.Set("fresh", "verifiableCredentials/authorities/1", fresh, map[string]interface{}{})
+	if _, ok := cache.Get("fresh"); !ok {
+		t.Error("expected the read which started after the invalidation to be cached")
+	}
+	cache.End(fresh)
+	cache.End(started)
+}
+
+func TestReadCache_InvalidationsPruned(t *testing.T) {
+	cache := NewReadCache(time.Minute)
+
+	cache.Invalidate("verifiableCredentials/authorities/1")
+	if len(cache.invalidated) != 0 {
+		t.Errorf("expected no invalidation to be kept without a running read, got %v", cache.invalidated)
+	}
+
+	first := cache.Begin()
+	cache.Invalidate("verifiableCredentials/authorities/1")
+	second := cache.Begin()
+	cache.Invalidate("verifiableCredentials/authorities/2")
+	cache.End(first)
+	if len(cache.invalidated) != 1 {
+		t.Errorf("expected the invalidation the second read started after to be dropped, got %v", cache.invalidated)
+	}
+	cache.End(second)
+	if len(cache.invalidated) != 0 || len(cache.running) != 0 {
+		t.Errorf("expected the invalidations to be dropped once the reads are done, got %v and %v", cache.invalidated, cache.running)
+	}
+}
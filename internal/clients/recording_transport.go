@@ -0,0 +1,254 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// RecordingMode determines whether the requests are sent to the service, recorded to a cassette or replayed from it.
+type RecordingMode string
+
+const (
+	RecordingModeLive   RecordingMode = "live"
+	RecordingModeRecord RecordingMode = "record"
+	RecordingModeReplay RecordingMode = "replay"
+)
+
+// ParseRecordingMode parses the value of the `VERIFIEDID_RECORDING_MODE` setting, an empty value means live.
+func ParseRecordingMode(v string) (RecordingMode, error) {
+	switch mode := RecordingMode(strings.ToLower(v)); mode {
+	case "", RecordingModeLive:
+		return RecordingModeLive, nil
+	case RecordingModeRecord, RecordingModeReplay:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown recording mode %q, supported values are: %s, %s, %s", v, RecordingModeLive, RecordingModeRecord, RecordingModeReplay)
+	}
+}
+
+type cassette struct {
+	path string
+	lock sync.Mutex
+	used []bool
+
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method  string      `json:"method"`
+	Url     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+var (
+	// cassettes are shared by path, as the provider builds a new client every time it's configured during a test.
+	cassettes     = make(map[string]*cassette)
+	cassettesLock = &sync.Mutex{}
+)
+
+// openCassette returns the cassette stored at path. In record mode the cassette starts empty, in replay mode it's
+// loaded from the file.
+func openCassette(path string, mode RecordingMode) (*cassette, error) {
+	cassettesLock.Lock()
+	defer cassettesLock.Unlock()
+
+	if c, ok := cassettes[path]; ok {
+		return c, nil
+	}
+
+	c := &cassette{
+		path:         path,
+		Interactions: make([]interaction, 0),
+	}
+	if mode == RecordingModeReplay {
+		// #nosec G304
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette %q: %v", path, err)
+		}
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, fmt.Errorf("decoding cassette %q: %v", path, err)
+		}
+		c.used = make([]bool, len(c.Interactions))
+	}
+	cassettes[path] = c
+	return c, nil
+}
+
+func (c *cassette) add(i interaction) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.Interactions = append(c.Interactions, i)
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o600)
+}
+
+// match returns the first unused interaction with the same method, path, query and body as the request. Once all of
+// them are used, the last matching interaction is served again, as reads can be repeated a different number of times.
+func (c *cassette) match(method, rawUrl, body string) (*interaction, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := matchKey(method, rawUrl, body)
+	last := -1
+	for i := range c.Interactions {
+		if matchKey(c.Interactions[i].Request.Method, c.Interactions[i].Request.Url, c.Interactions[i].Request.Body) != key {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return &c.Interactions[i], nil
+		}
+		last = i
+	}
+	if last == -1 {
+		return nil, replayError{fmt.Errorf("no interaction recorded in cassette %q matches %s %s", c.path, method, rawUrl)}
+	}
+	return &c.Interactions[last], nil
+}
+
+// matchKey is the method, path, query and body of a request. The query parameters are sorted by url.Values.Encode
+// and the body is re-encoded, so the key doesn't depend on the order of the parameters or of the JSON fields.
+func matchKey(method, rawUrl, body string) string {
+	path, query := rawUrl, ""
+	if u, err := url.Parse(rawUrl); err == nil {
+		path = u.Path
+		query = u.Query().Encode()
+	}
+	return strings.Join([]string{strings.ToUpper(method), path, query, body}, "\n")
+}
+
+// replayError is returned when a request can't be replayed, it's not retried by the pipeline.
+type replayError struct {
+	error
+}
+
+func (replayError) NonRetriable() {}
+
+type recordingTransport struct {
+	mode      RecordingMode
+	cassette  *cassette
+	transport policy.Transporter
+	redactor  *redactor
+}
+
+// NewRecordingTransport returns a transport which records the requests sent by transport to the cassette at path, or
+// replays them from it, depending on mode. The values matching the redaction rules are never written to the cassette.
+func NewRecordingTransport(mode RecordingMode, path string, transport policy.Transporter, rules RedactionRules) (policy.Transporter, error) {
+	r, err := newRedactor(rules)
+	if err != nil {
+		return nil, err
+	}
+	c, err := openCassette(path, mode)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingTransport{
+		mode:      mode,
+		cassette:  c,
+		transport: transport,
+		redactor:  r,
+	}, nil
+}
+
+func (t *recordingTransport) Do(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		requestBody = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	if t.mode == RecordingModeReplay {
+		i, err := t.cassette.match(req.Method, req.URL.String(), t.redactor.redactBody(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode:    i.Response.StatusCode,
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.transport.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	err = t.cassette.add(interaction{
+		Request: recordedRequest{
+			Method:  req.Method,
+			Url:     req.URL.String(),
+			Headers: t.redactor.redactHeaders(req.Header),
+			Body:    t.redactor.redactBody(requestBody),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    t.redactor.redactHeaders(resp.Header),
+			Body:       t.redactor.redactBody(responseBody),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recording %s %s to cassette %q: %v", req.Method, req.URL.String(), t.cassette.path, err)
+	}
+	return resp, nil
+}
+
+// replayCredential is used in replay mode, where no request reaches the service and no access token is needed.
+type replayCredential struct{}
+
+func (replayCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{
+		Token:     redactedValue,
+		ExpiresOn: time.Now().Add(time.Hour),
+	}, nil
+}
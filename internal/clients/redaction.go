@@ -0,0 +1,158 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+const redactedValue = "REDACTED"
+
+// RedactionRules are the headers and the body fields whose values are never written to the traffic log, the traffic
+// log file or a cassette.
+type RedactionRules struct {
+	// Headers are the names of the redacted headers, they're case-insensitive.
+	Headers []string
+	// JSONPaths are JSON Pointers to the redacted fields of the JSON bodies. A `*` token matches any member or element,
+	// a `**` token any number of levels, and the member names are case-insensitive.
+	JSONPaths []string
+}
+
+// DefaultRedactionRules are always applied. They cover the credentials of the requests, the tokens and secrets of the
+// identity providers, such as the client secret of an `idTokens` attestation, and the claims, PIN and callback headers
+// of the issuance requests.
+var DefaultRedactionRules = RedactionRules{
+	Headers: []string{
+		"Authorization",
+		"Proxy-Authorization",
+		"Cookie",
+		"Set-Cookie",
+	},
+	JSONPaths: []string{
+		"/**/access_token",
+		"/**/refresh_token",
+		"/**/id_token",
+		"/**/vp_token",
+		"/**/client_secret",
+		"/**/clientSecret",
+		"/**/client_assertion",
+		"/**/password",
+		"/**/idTokenHint",
+		"/claims",
+		"/pin/value",
+		"/callback/headers",
+		"/callback/headers/*",
+	},
+}
+
+type redactor struct {
+	headers map[string]bool
+	paths   [][]string
+}
+
+// newRedactor returns a redactor applying the default rules and the given ones.
+func newRedactor(rules RedactionRules) (*redactor, error) {
+	r := &redactor{
+		headers: make(map[string]bool),
+		paths:   make([][]string, 0),
+	}
+	for _, rules := range []RedactionRules{DefaultRedactionRules, rules} {
+		for _, name := range rules.Headers {
+			r.headers[strings.ToLower(name)] = true
+		}
+		for _, pointer := range rules.JSONPaths {
+			tokens, err := utils.ParseJSONPointer(pointer)
+			if err != nil {
+				return nil, fmt.Errorf("parsing redaction rule: %v", err)
+			}
+			r.paths = append(r.paths, tokens)
+		}
+	}
+	return r, nil
+}
+
+func (r *redactor) isRedactedHeader(name string) bool {
+	return r.headers[strings.ToLower(name)]
+}
+
+func (r *redactor) redactHeaders(headers http.Header) http.Header {
+	out := headers.Clone()
+	for name, values := range out {
+		if r.isRedactedHeader(name) {
+			for i := range values {
+				values[i] = redactedValue
+			}
+		}
+	}
+	return out
+}
+
+// redactBody replaces the values of the redacted fields of a JSON body and re-encodes it, so its members are sorted.
+// Other bodies are returned as they are.
+func (r *redactor) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return string(body)
+	}
+	for _, tokens := range r.paths {
+		v = redactPath(v, tokens)
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return string(body)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// redactPath replaces the values the tokens of a JSON Pointer refer to in v.
+func redactPath(v interface{}, tokens []string) interface{} {
+	if len(tokens) == 0 {
+		return redactedValue
+	}
+
+	token := tokens[0]
+	if token == "**" {
+		// match the rest of the path at this level, then at every level below
+		v = redactPath(v, tokens[1:])
+		switch value := v.(type) {
+		case map[string]interface{}:
+			for key, item := range value {
+				value[key] = redactPath(item, tokens)
+			}
+		case []interface{}:
+			for i, item := range value {
+				value[i] = redactPath(item, tokens)
+			}
+		}
+		return v
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if token == "*" || strings.EqualFold(key, token) {
+				value[key] = redactPath(item, tokens[1:])
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			if token == "*" || token == strconv.Itoa(i) {
+				value[i] = redactPath(item, tokens[1:])
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,104 @@
+package clients
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRedactor_RedactBody(t *testing.T) {
+	r, err := newRedactor(RedactionRules{
+		JSONPaths: []string{"/displays/*/card/issuedBy", "/items/1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "empty",
+			body: "",
+			want: "",
+		},
+		{
+			name: "not json",
+			body: "client_secret=secret",
+			want: "client_secret=secret",
+		},
+		{
+			name: "sorted and not escaped",
+			body: `{"b":"<b>","a":12345678901234567890}`,
+			want: `{"a":12345678901234567890,"b":"<b>"}`,
+		},
+		{
+			name: "id tokens attestation client secret",
+			body: `{"rules":{"attestations":{"idTokens":[{"clientId":"id","clientSecret":"secret"}]}}}`,
+			want: `{"rules":{"attestations":{"idTokens":[{"clientId":"id","clientSecret":"REDACTED"}]}}}`,
+		},
+		{
+			name: "issuance request claims and pin",
+			body: `{"callback":{"url":"https://contoso.com"},"claims":{"given_name":"Megan"},"pin":{"length":4,"value":"1234"}}`,
+			want: `{"callback":{"url":"https://contoso.com"},"claims":"REDACTED","pin":{"length":4,"value":"REDACTED"}}`,
+		},
+		{
+			name: "issuance request callback headers",
+			body: `{"callback":{"headers":{"api-key":"secret"},"state":"smoke","url":"https://contoso.com"}}`,
+			want: `{"callback":{"headers":"REDACTED","state":"smoke","url":"https://contoso.com"}}`,
+		},
+		{
+			name: "nested claims are kept",
+			body: `{"displays":[{"claims":[{"claim":"vc.credentialSubject.name"}]}]}`,
+			want: `{"displays":[{"claims":[{"claim":"vc.credentialSubject.name"}]}]}`,
+		},
+		{
+			name: "configured paths",
+			body: `{"displays":[{"card":{"issuedBy":"Contoso","title":"Card"}}],"items":["a","b","c"]}`,
+			want: `{"displays":[{"card":{"issuedBy":"REDACTED","title":"Card"}}],"items":["a","REDACTED","c"]}`,
+		},
+		{
+			name: "case-insensitive at any depth",
+			body: `[{"token":{"Access_Token":"secret"}}]`,
+			want: `[{"token":{"Access_Token":"REDACTED"}}]`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := r.redactBody([]byte(tc.body)); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRedactor_RedactHeaders(t *testing.T) {
+	r, err := newRedactor(RedactionRules{
+		Headers: []string{"X-Api-Key"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer secret")
+	headers.Set("X-Api-Key", "secret")
+	headers.Set("Content-Type", "application/json")
+	got := r.redactHeaders(headers)
+	if got.Get("Authorization") != redactedValue || got.Get("X-Api-Key") != redactedValue {
+		t.Errorf("expected the authorization and api key headers to be redacted, got %v", got)
+	}
+	if got.Get("Content-Type") != "application/json" {
+		t.Errorf("expected the content type header to be kept, got %v", got)
+	}
+	if headers.Get("Authorization") != "Bearer secret" {
+		t.Error("expected the original headers to be left unchanged")
+	}
+}
+
+func TestNewRedactor_InvalidPath(t *testing.T) {
+	if _, err := newRedactor(RedactionRules{JSONPaths: []string{"rules/attestations"}}); err == nil {
+		t.Error("expected an error for a path which isn't a JSON pointer")
+	}
+}
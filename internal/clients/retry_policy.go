@@ -0,0 +1,151 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const (
+	defaultMaxRetries    = 3
+	defaultRetryDelay    = 800 * time.Millisecond
+	defaultMaxRetryDelay = 60 * time.Second
+)
+
+// RetryOptions configures the retries of a request. It has the semantics of policy.RetryOptions, the jitter of the
+// delays between the attempts can be disabled in addition.
+type RetryOptions struct {
+	policy.RetryOptions
+
+	// DisableJitter makes the delays grow exactly exponentially, rather than randomizing them by -20% to +30%.
+	DisableJitter bool
+}
+
+type ctxRetryOptionsKey struct{}
+
+// withRetryOptions overrides the retry options of the requests sent with the context.
+func withRetryOptions(ctx context.Context, options RetryOptions) context.Context {
+	return context.WithValue(ctx, ctxRetryOptionsKey{}, options)
+}
+
+// retryPolicy replaces the azcore retry policy, which is disabled in the pipeline, to support the options it lacks.
+// It's the last per-call policy, so the per-retry policies run for every attempt.
+type retryPolicy struct {
+	options RetryOptions
+}
+
+func NewRetryPolicy(options *RetryOptions) policy.Policy {
+	if options == nil {
+		options = &RetryOptions{}
+	}
+	return &retryPolicy{options: *options}
+}
+
+func (p *retryPolicy) Do(req *policy.Request) (resp *http.Response, err error) {
+	options := p.options
+	if override, ok := req.Raw().Context().Value(ctxRetryOptionsKey{}).(RetryOptions); ok {
+		options = override
+	}
+	setRetryDefaults(&options)
+
+	for try := int32(1); ; try++ {
+		if err = req.RewindBody(); err != nil {
+			return nil, err
+		}
+		resp, err = req.Clone(req.Raw().Context()).Next()
+
+		if ctxErr := req.Raw().Context().Err(); ctxErr != nil {
+			return resp, ctxErr
+		}
+
+		var nonRetriable interface{ NonRetriable() }
+		if errors.As(err, &nonRetriable) {
+			return resp, err
+		}
+
+		if options.ShouldRetry != nil {
+			if !options.ShouldRetry(resp, err) {
+				return resp, err
+			}
+		} else if err == nil && !runtime.HasStatusCode(resp, options.StatusCodes...) {
+			return resp, err
+		}
+
+		if try == options.MaxRetries+1 {
+			log.Printf("[DEBUG] Giving up %s %s after %d attempts", req.Raw().Method, req.Raw().URL.Path, try)
+			return resp, err
+		}
+
+		delay := time.Duration(0)
+		if resp != nil {
+			delay = retryAfter(resp)
+		}
+		if delay <= 0 {
+			delay = retryDelay(options, try)
+		} else if delay > options.MaxRetryDelay {
+			log.Printf("[DEBUG] Retry-After of %s exceeds the maximum delay of %s, giving up", delay, options.MaxRetryDelay)
+			return resp, err
+		}
+
+		if resp != nil {
+			runtime.Drain(resp)
+		}
+		log.Printf("[DEBUG] Retrying %s %s in %s, attempt %d", req.Raw().Method, req.Raw().URL.Path, delay, try+1)
+		if err := sleep(req.Raw().Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// setRetryDefaults fills in the defaults of policy.RetryOptions.
+func setRetryDefaults(o *RetryOptions) {
+	if o.MaxRetries == 0 {
+		o.MaxRetries = defaultMaxRetries
+	} else if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	}
+	if o.MaxRetryDelay == 0 {
+		o.MaxRetryDelay = defaultMaxRetryDelay
+	} else if o.MaxRetryDelay < 0 {
+		o.MaxRetryDelay = math.MaxInt64
+	}
+	if o.RetryDelay == 0 {
+		o.RetryDelay = defaultRetryDelay
+	} else if o.RetryDelay < 0 {
+		o.RetryDelay = 0
+	}
+	if o.StatusCodes == nil {
+		o.StatusCodes = DefaultRetryableStatusCodes
+	}
+}
+
+// retryDelay returns the delay after the given attempt, (2^try - 1) * RetryDelay capped at MaxRetryDelay.
+func retryDelay(o RetryOptions, try int32) time.Duration {
+	delay := time.Duration(math.MaxInt64)
+	if try < 63 {
+		factor := time.Duration(int64(1)<<try - 1)
+		if o.RetryDelay == 0 || factor <= math.MaxInt64/o.RetryDelay {
+			delay = factor * o.RetryDelay
+		}
+	}
+
+	if !o.DisableJitter {
+		// randomize by [0.8, 1.3), as the azcore retry policy does
+		jittered := float64(delay) * (rand.Float64()/2 + 0.8) // #nosec G404
+		if jittered < float64(math.MaxInt64) {
+			delay = time.Duration(jittered)
+		}
+	}
+
+	if delay > o.MaxRetryDelay {
+		delay = o.MaxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,161 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+)
+
+func newTestRetryValue(attributes map[string]attr.Value) retry.Value {
+	values := map[string]attr.Value{
+		"error_message_regex": types.ListNull(types.StringType),
+		"status_codes":        types.ListNull(types.Int64Type),
+		"max_attempts":        types.Int64Null(),
+		"min_delay":           types.StringNull(),
+		"max_delay":           types.StringNull(),
+		"jitter":              types.BoolNull(),
+		"operations":          types.ListNull(types.StringType),
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+	return retry.NewRetryValueMust(retry.Value{}.AttributeTypes(context.Background()), values)
+}
+
+func TestRetryPolicy_MaxAttemptsAndStatusCodes(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusConflict)
+	}))
+	t.Cleanup(server.Close)
+
+	pl := runtime.NewPipeline(moduleName, moduleVersion, runtime.PipelineOptions{
+		PerCall: []policy.Policy{NewRetryPolicy(nil)},
+	}, &policy.ClientOptions{
+		Retry: policy.RetryOptions{MaxRetries: -1},
+	})
+
+	rtry := newTestRetryValue(map[string]attr.Value{
+		"status_codes": types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(http.StatusConflict)}),
+		"max_attempts": types.Int64Value(3),
+		"min_delay":    types.StringValue("10ms"),
+		"jitter":       types.BoolValue(false),
+	})
+	ctx := withRetryOptions(context.Background(), *NewRetryOptions(rtry, retry.OperationCreate))
+	req, err := runtime.NewRequest(ctx, http.MethodPost, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	resp, err := pl.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusConflict {
+		t.Errorf("expected the last response to be returned, got %d", resp.StatusCode)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 attempts, got %d", requests)
+	}
+	// without jitter, the delays are exactly 10ms and 30ms
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected the retries to wait at least 40ms, waited %s", elapsed)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	options := RetryOptions{
+		RetryOptions: policy.RetryOptions{
+			RetryDelay:    time.Second,
+			MaxRetryDelay: 5 * time.Second,
+		},
+		DisableJitter: true,
+	}
+	for try, want := range map[int32]time.Duration{1: time.Second, 2: 3 * time.Second, 3: 5 * time.Second, 100: 5 * time.Second} {
+		if got := retryDelay(options, try); got != want {
+			t.Errorf("expected the delay after attempt %d to be %s, got %s", try, want, got)
+		}
+	}
+
+	options.DisableJitter = false
+	for i := 0; i < 100; i++ {
+		if got := retryDelay(options, 1); got < 800*time.Millisecond || got >= 1300*time.Millisecond {
+			t.Fatalf("expected the jittered delay to be within [800ms, 1300ms), got %s", got)
+		}
+	}
+}
+
+func TestNewRetryOptions(t *testing.T) {
+	if options := NewRetryOptions(retry.NewValueNull(), retry.OperationRead); options != nil {
+		t.Errorf("expected no retry options for a null retry, got %+v", options)
+	}
+
+	rtry := newTestRetryValue(map[string]attr.Value{
+		"max_attempts": types.Int64Value(1),
+		"max_delay":    types.StringValue("1m"),
+		"operations":   types.ListValueMust(types.StringType, []attr.Value{types.StringValue(retry.OperationCreate)}),
+	})
+	if options := NewRetryOptions(rtry, retry.OperationRead); options != nil {
+		t.Errorf("expected no retry options for an operation the retry doesn't apply to, got %+v", options)
+	}
+
+	options := NewRetryOptions(rtry, retry.OperationCreate)
+	if options == nil {
+		t.Fatal("expected retry options for the create operation")
+	}
+	if options.MaxRetries != -1 {
+		t.Errorf("expected a single attempt to disable the retries, got MaxRetries %d", options.MaxRetries)
+	}
+	if options.MaxRetryDelay != time.Minute {
+		t.Errorf("expected a max delay of 1m, got %s", options.MaxRetryDelay)
+	}
+	if options.DisableJitter {
+		t.Error("expected the jitter to default to enabled")
+	}
+	if options.ShouldRetry(nil, nil) {
+		t.Error("expected no retry without a response or an error")
+	}
+}
+
+func TestMSGraphClient_RetryOptions(t *testing.T) {
+	client := &MSGraphClient{
+		defaultRetry: newTestRetryValue(map[string]attr.Value{
+			"max_delay": types.StringValue("2m"),
+		}),
+	}
+	rtry := newTestRetryValue(map[string]attr.Value{
+		"max_delay":  types.StringValue("1m"),
+		"operations": types.ListValueMust(types.StringType, []attr.Value{types.StringValue(retry.OperationCreate)}),
+	})
+
+	if options := client.RetryOptions(rtry, retry.OperationCreate); options == nil || options.MaxRetryDelay != time.Minute {
+		t.Errorf("expected the retry of the resource for the create operation, got %+v", options)
+	}
+	if options := client.RetryOptions(rtry, retry.OperationRead); options == nil || options.MaxRetryDelay != 2*time.Minute {
+		t.Errorf("expected the retry of the provider for an operation the retry of the resource leaves out, got %+v", options)
+	}
+	if options := client.RetryOptions(retry.NewValueNull(), retry.OperationRead); options == nil || options.MaxRetryDelay != 2*time.Minute {
+		t.Errorf("expected the retry of the provider without a retry of the resource, got %+v", options)
+	}
+}
+
+func TestCombineRetryOptions_Jitter(t *testing.T) {
+	withoutJitter := &RetryOptions{DisableJitter: true}
+	if combined := CombineRetryOptions(withoutJitter, withoutJitter); !combined.DisableJitter {
+		t.Error("expected the jitter to stay disabled when all options disable it")
+	}
+	if combined := CombineRetryOptions(withoutJitter, &RetryOptions{}); combined.DisableJitter {
+		t.Error("expected the jitter to be enabled when any option enables it")
+	}
+}
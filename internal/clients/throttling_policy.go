@@ -0,0 +1,185 @@
+package clients
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+const (
+	// throttlingDefaultPause is how long the requests are paused when the service throttles without a Retry-After.
+	throttlingDefaultPause = 5 * time.Second
+	// throttlingMaxPause caps the pause requested by the service, the retry policy still honours the full Retry-After.
+	throttlingMaxPause = 5 * time.Minute
+)
+
+// retryAfterHeaders are the headers the service uses to tell how long to wait, the ones in milliseconds come first.
+var retryAfterHeaders = []struct {
+	name string
+	unit time.Duration
+}{
+	{"Retry-After-Ms", time.Millisecond},
+	{"X-Ms-Retry-After-Ms", time.Millisecond},
+	{"Retry-After", time.Second},
+}
+
+// rateLimitRemainingHeaders are the headers with the number of requests left before the service throttles.
+var rateLimitRemainingHeaders = []string{
+	"X-Ms-Ratelimit-Remaining-Subscription-Reads",
+	"X-Ms-Ratelimit-Remaining-Subscription-Writes",
+	"X-Ms-Ratelimit-Remaining-Tenant-Reads",
+	"X-Ms-Ratelimit-Remaining-Tenant-Writes",
+}
+
+// ThrottlingPolicy limits the requests sent by all the resources of the provider. It bounds the number of requests in
+// flight and their rate, and pauses every request when a response reports throttling, rather than letting each request
+// back off on its own.
+type ThrottlingPolicy struct {
+	slots chan struct{}
+
+	lock        sync.Mutex
+	interval    time.Duration
+	next        time.Time
+	pausedUntil time.Time
+}
+
+// NewThrottlingPolicy returns a policy allowing maxConcurrentRequests requests in flight and requestsPerSecond requests
+// per second, 0 means no limit. The pause on throttling applies regardless of the limits.
+func NewThrottlingPolicy(maxConcurrentRequests int, requestsPerSecond float64) *ThrottlingPolicy {
+	p := &ThrottlingPolicy{}
+	if maxConcurrentRequests > 0 {
+		p.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+	if requestsPerSecond > 0 {
+		p.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return p
+}
+
+func (p *ThrottlingPolicy) Do(req *policy.Request) (*http.Response, error) {
+	ctx := req.Raw().Context()
+
+	if p.slots != nil {
+		select {
+		case p.slots <- struct{}{}:
+			defer func() { <-p.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := sleep(ctx, p.reserve()); err != nil {
+		return nil, err
+	}
+
+	resp, err := req.Next()
+	if resp != nil {
+		p.adapt(resp)
+	}
+	return resp, err
+}
+
+// reserve returns how long to wait before sending the request, for the pause to end and for the next free slot of the
+// rate limit, and reserves that slot.
+func (p *ThrottlingPolicy) reserve() time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	at := now
+	if p.pausedUntil.After(at) {
+		at = p.pausedUntil
+	}
+	if p.interval > 0 {
+		if p.next.After(at) {
+			at = p.next
+		}
+		p.next = at.Add(p.interval)
+	}
+	return at.Sub(now)
+}
+
+// adapt pauses the requests when the response is throttled, or when it reports that no request is left.
+func (p *ThrottlingPolicy) adapt(resp *http.Response) {
+	pause := time.Duration(0)
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		pause = retryAfter(resp)
+		if pause == 0 {
+			pause = throttlingDefaultPause
+		}
+	case resp.StatusCode == http.StatusServiceUnavailable:
+		pause = retryAfter(resp)
+	case rateLimitExhausted(resp):
+		pause = retryAfter(resp)
+		if pause == 0 {
+			pause = throttlingDefaultPause
+		}
+	}
+	if pause <= 0 {
+		return
+	}
+	if pause > throttlingMaxPause {
+		pause = throttlingMaxPause
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if until := time.Now().Add(pause); until.After(p.pausedUntil) {
+		log.Printf("[DEBUG] Throttled by %s %s, pausing all requests for %s", resp.Request.Method, resp.Request.URL.Path, pause)
+		p.pausedUntil = until
+	}
+}
+
+// retryAfter returns the delay requested by the response, 0 if there is none.
+func retryAfter(resp *http.Response) time.Duration {
+	for _, header := range retryAfterHeaders {
+		v := strings.TrimSpace(resp.Header.Get(header.name))
+		if v == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(v); err == nil {
+			if n > 0 {
+				return time.Duration(n) * header.unit
+			}
+			continue
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return time.Until(t)
+		}
+	}
+	return 0
+}
+
+func rateLimitExhausted(resp *http.Response) bool {
+	for _, name := range rateLimitRemainingHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n <= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var _ policy.Policy = &ThrottlingPolicy{}
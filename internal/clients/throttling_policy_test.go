@@ -0,0 +1,124 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func newThrottledTestPipeline(t *testing.T, throttling *ThrottlingPolicy, handler http.HandlerFunc) (runtime.Pipeline, string) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	pl := runtime.NewPipeline(moduleName, moduleVersion, runtime.PipelineOptions{
+		PerRetry: []policy.Policy{throttling},
+	}, &policy.ClientOptions{
+		Retry: policy.RetryOptions{MaxRetries: -1},
+	})
+	return pl, server.URL
+}
+
+func sendTestRequest(t *testing.T, pl runtime.Pipeline, url string) *http.Response {
+	req, err := runtime.NewRequest(context.Background(), http.MethodGet, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := pl.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestThrottlingPolicy_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	pl, url := newThrottledTestPipeline(t, NewThrottlingPolicy(2, 0), func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sendTestRequest(t, pl, url)
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestThrottlingPolicy_RequestsPerSecond(t *testing.T) {
+	pl, url := newThrottledTestPipeline(t, NewThrottlingPolicy(0, 20), func(w http.ResponseWriter, r *http.Request) {})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		sendTestRequest(t, pl, url)
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected 5 requests at 20 per second to take at least 200ms, took %s", elapsed)
+	}
+}
+
+func TestThrottlingPolicy_PausesOnRetryAfter(t *testing.T) {
+	var requests int32
+	pl, url := newThrottledTestPipeline(t, NewThrottlingPolicy(0, 0), func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+
+	if resp := sendTestRequest(t, pl, url); resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the first request to be throttled, got %d", resp.StatusCode)
+	}
+
+	start := time.Now()
+	if resp := sendTestRequest(t, pl, url); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the second request to succeed, got %d", resp.StatusCode)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected the second request to wait for the Retry-After, waited %s", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	testcases := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+	}{
+		{name: "none", want: 0},
+		{name: "seconds", headers: map[string]string{"Retry-After": "3"}, want: 3 * time.Second},
+		{name: "milliseconds first", headers: map[string]string{"Retry-After": "3", "Retry-After-Ms": "250"}, want: 250 * time.Millisecond},
+		{name: "invalid", headers: map[string]string{"Retry-After": "soon"}, want: 0},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			for key, value := range tc.headers {
+				resp.Header.Set(key, value)
+			}
+			if got := retryAfter(resp); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracerName is the instrumentation scope of the spans of the provider.
+	TracerName = "github.com/mjendza/terraform-provider-verifiedid"
+
+	// TracesExporterEnv lists the exporters of the spans, `otlp`, `file` or `none`, separated by commas.
+	TracesExporterEnv = "OTEL_TRACES_EXPORTER"
+	// TracesFileEnv is the file the `file` exporter appends the spans to, one JSON object per line.
+	TracesFileEnv = "OTEL_EXPORTER_FILE_PATH"
+
+	tracesProtocolEnv = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
+	protocolEnv       = "OTEL_EXPORTER_OTLP_PROTOCOL"
+
+	serviceName = "terraform-provider-verifiedid"
+)
+
+// requestIDHeaders are the response headers the service identifies a request with.
+var requestIDHeaders = map[string]string{
+	"X-Ms-Request-Id":   "az.service_request_id",
+	"Request-Id":        "az.request_id",
+	"Client-Request-Id": "az.client_request_id",
+}
+
+// NewTracerProvider returns the tracer provider of the exporters configured by the standard `OTEL_*` environment
+// variables, and the function flushing the spans and releasing the exporters. It returns nil when no exporter is
+// configured, so the spans of the provider aren't recorded at all.
+//
+// The `otlp` exporter sends the spans to an OTLP/HTTP endpoint, configured by the `OTEL_EXPORTER_OTLP_*` variables.
+// The `file` exporter appends them to the file at `OTEL_EXPORTER_FILE_PATH`. The service name and the resource
+// attributes can be overridden with `OTEL_SERVICE_NAME` and `OTEL_RESOURCE_ATTRIBUTES`, and the sampler with
+// `OTEL_TRACES_SAMPLER`.
+func NewTracerProvider(ctx context.Context, serviceVersion string) (trace.TracerProvider, func(context.Context) error, error) {
+	var exporters []string
+	for _, exporter := range strings.Split(os.Getenv(TracesExporterEnv), ",") {
+		if exporter = strings.ToLower(strings.TrimSpace(exporter)); exporter != "" && exporter != "none" {
+			exporters = append(exporters, exporter)
+		}
+	}
+	if len(exporters) == 0 {
+		return nil, nil, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", serviceVersion),
+		),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building the OpenTelemetry resource: %+v", err)
+	}
+
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	var files []*os.File
+	closeFiles := func() error {
+		var errs []error
+		for _, f := range files {
+			errs = append(errs, f.Close())
+		}
+		return errors.Join(errs...)
+	}
+	for _, exporter := range exporters {
+		switch exporter {
+		case "otlp":
+			protocol := os.Getenv(tracesProtocolEnv)
+			if protocol == "" {
+				protocol = os.Getenv(protocolEnv)
+			}
+			if protocol != "" && protocol != "http/protobuf" {
+				_ = closeFiles()
+				return nil, nil, fmt.Errorf("the OTLP protocol %q is not supported, only `http/protobuf` is", protocol)
+			}
+			e, err := otlptracehttp.New(ctx)
+			if err != nil {
+				_ = closeFiles()
+				return nil, nil, fmt.Errorf("creating the OTLP exporter: %+v", err)
+			}
+			options = append(options, sdktrace.WithBatcher(e))
+		case "file":
+			path := os.Getenv(TracesFileEnv)
+			if path == "" {
+				_ = closeFiles()
+				return nil, nil, fmt.Errorf("%s must be set to use the `file` exporter", TracesFileEnv)
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+			if err != nil {
+				_ = closeFiles()
+				return nil, nil, fmt.Errorf("opening the traces file %q: %+v", path, err)
+			}
+			files = append(files, f)
+			e, err := stdouttrace.New(stdouttrace.WithWriter(f))
+			if err != nil {
+				_ = closeFiles()
+				return nil, nil, fmt.Errorf("creating the file exporter: %+v", err)
+			}
+			// The spans are written as they end, the provider process may be killed before it flushes a batch.
+			options = append(options, sdktrace.WithSyncer(e))
+		default:
+			_ = closeFiles()
+			return nil, nil, fmt.Errorf("the traces exporter %q is not supported, use `otlp`, `file` or `none`", exporter)
+		}
+	}
+
+	tp := sdktrace.NewTracerProvider(options...)
+	shutdown := func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), closeFiles())
+	}
+	return tp, shutdown, nil
+}
+
+// TracingPolicy records a client span for each attempt of a request, as a child of the span of the Terraform operation
+// sending it, with the correlation ID of the provider and the request IDs returned by the service.
+type TracingPolicy struct {
+	tracer trace.Tracer
+}
+
+// NewTracingPolicy returns a policy recording the requests with the tracers of tp.
+func NewTracingPolicy(tp trace.TracerProvider) *TracingPolicy {
+	return &TracingPolicy{
+		tracer: tp.Tracer(TracerName),
+	}
+}
+
+func (p *TracingPolicy) Do(req *policy.Request) (*http.Response, error) {
+	rawRequest := req.Raw()
+	// The query is left out, the filters may hold the values of the resources.
+	url := *rawRequest.URL
+	url.RawQuery = ""
+	url.User = nil
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", rawRequest.Method),
+		attribute.String("url.full", url.String()),
+		attribute.String("server.address", rawRequest.URL.Hostname()),
+	}
+	if id := rawRequest.Header.Get(HeaderCorrelationRequestID); id != "" {
+		attributes = append(attributes, attribute.String("az.correlation_request_id", id))
+	}
+	ctx, span := p.tracer.Start(rawRequest.Context(), "HTTP "+rawRequest.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	defer span.End()
+
+	resp, err := req.WithContext(ctx).Next()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	for header, key := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			span.SetAttributes(attribute.String(key, id))
+		}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, err
+}
+
+var _ policy.Policy = &TracingPolicy{}
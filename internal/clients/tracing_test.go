@@ -0,0 +1,140 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracingPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ms-Request-Id", "request-id")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	pl := runtime.NewPipeline(moduleName, moduleVersion, runtime.PipelineOptions{
+		PerCall:  []policy.Policy{withCorrelationRequestID("correlation-id")},
+		PerRetry: []policy.Policy{NewTracingPolicy(tp)},
+	}, &policy.ClientOptions{
+		Retry: policy.RetryOptions{MaxRetries: -1},
+	})
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "verifiedid_contract read")
+	req, err := runtime.NewRequest(ctx, http.MethodGet, server.URL+"/beta/verifiableCredentials/authorities?$filter=secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pl.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "HTTP GET" {
+		t.Errorf("expected the span HTTP GET, got %q", span.Name())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("expected the span to be a child of the operation span")
+	}
+	if v := spanAttribute(span, "url.full").AsString(); v != server.URL+"/beta/verifiableCredentials/authorities" {
+		t.Errorf("expected the url without its query, got %q", v)
+	}
+	if v := spanAttribute(span, "az.correlation_request_id").AsString(); v != "correlation-id" {
+		t.Errorf("expected the correlation ID, got %q", v)
+	}
+	if v := spanAttribute(span, "az.service_request_id").AsString(); v != "request-id" {
+		t.Errorf("expected the request ID, got %q", v)
+	}
+	if v := spanAttribute(span, "http.response.status_code").AsInt64(); v != http.StatusNotFound {
+		t.Errorf("expected the status code 404, got %d", v)
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("expected an error status, got %v", span.Status().Code)
+	}
+}
+
+func TestNewTracerProvider_Disabled(t *testing.T) {
+	for _, value := range []string{"", "none"} {
+		t.Setenv(TracesExporterEnv, value)
+		tp, shutdown, err := NewTracerProvider(context.Background(), "dev")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tp != nil || shutdown != nil {
+			t.Errorf("expected no tracer provider for %q", value)
+		}
+	}
+}
+
+func TestNewTracerProvider_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv(TracesExporterEnv, "file")
+	t.Setenv(TracesFileEnv, path)
+
+	tp, shutdown, err := NewTracerProvider(context.Background(), "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tp.Tracer(TracerName).Start(context.Background(), "verifiedid_authority create")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"Name":"verifiedid_authority create"`) {
+		t.Errorf("expected the span in the traces file, got %s", data)
+	}
+	if !strings.Contains(string(data), `"Value":"terraform-provider-verifiedid"`) {
+		t.Errorf("expected the service name in the traces file, got %s", data)
+	}
+}
+
+func TestNewTracerProvider_Invalid(t *testing.T) {
+	t.Setenv(TracesExporterEnv, "file")
+	t.Setenv(TracesFileEnv, "")
+	if _, _, err := NewTracerProvider(context.Background(), "dev"); err == nil {
+		t.Error("expected an error without a traces file")
+	}
+
+	t.Setenv(TracesExporterEnv, "zipkin")
+	if _, _, err := NewTracerProvider(context.Background(), "dev"); err == nil {
+		t.Error("expected an error for an unsupported exporter")
+	}
+
+	t.Setenv(TracesExporterEnv, "otlp")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+	if _, _, err := NewTracerProvider(context.Background(), "dev"); err == nil {
+		t.Error("expected an error for the grpc protocol")
+	}
+}
@@ -6,6 +6,10 @@ func ApiVersion() string {
 	return "The API version of the data source. The allowed values are `v1.0` and `beta`. Defaults to `v1.0`."
 }
 
+func TargetAPI() string {
+	return "The API the requests are sent to. The allowed values are `verifiedid`, the Verified ID Admin API, `graph`, Microsoft Graph, for example to manage the app registration and the service principal of a Verified ID setup, and `request_service`, the Request Service API, for example to create issuance and presentation requests. Defaults to `verifiedid`."
+}
+
 func Url(kind string) string {
 	switch kind {
 	case "data":
@@ -85,3 +89,7 @@ func ResourceID() string {
 func IgnoreMissingProperty() string {
 	return "Whether ignore not returned properties like credentials in `body` to suppress plan-diff. Defaults to `true`. It's recommend to enable this option when some sensitive properties are not returned in response body, instead of setting them in `lifecycle.ignore_changes` because it will make the sensitive fields unable to update."
 }
+
+func DisableETag() string {
+	return "Whether to send the updates without the `If-Match` header. By default, the `ETag` captured when the resource was last read is sent with each update, and the update fails if the resource was changed outside Terraform in the meantime. Set it to `true` for the endpoints which don't support ETags. Defaults to `false`."
+}
@@ -0,0 +1,47 @@
+package myvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestStringIsDuration_ValidateString(t *testing.T) {
+	v := stringIsDuration{}
+
+	cases := []struct {
+		name      string
+		value     string
+		wantError bool
+	}{
+		{name: "seconds", value: "10s", wantError: false},
+		{name: "composite", value: "1m30s", wantError: false},
+		{name: "milliseconds", value: "500ms", wantError: false},
+		{name: "zero", value: "0s", wantError: true},
+		{name: "negative", value: "-5s", wantError: true},
+		{name: "no unit", value: "10", wantError: true},
+		{name: "empty", value: "", wantError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: basetypes.NewStringValue(tc.value),
+				Path:        path.Empty(),
+			}
+			resp := &validator.StringResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+
+			v.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("expected error: %v, got: %v", tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package myvalidator
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+type stringIsJSONPointer struct{}
+
+func (v stringIsJSONPointer) Description(ctx context.Context) string {
+	return "validates that the string is a JSON pointer, such as /rules/attestations"
+}
+
+func (v stringIsJSONPointer) MarkdownDescription(ctx context.Context) string {
+	return "validates that the string is a JSON pointer, such as `/rules/attestations`"
+}
+
+func (stringIsJSONPointer) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	str := req.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if str.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON pointer",
+			"The value must not be empty, the empty JSON pointer refers to the whole document.",
+		)
+		return
+	}
+
+	if _, err := utils.ParseJSONPointer(str.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON pointer",
+			err.Error(),
+		)
+	}
+}
+
+func StringIsJSONPointer() validator.String {
+	return stringIsJSONPointer{}
+}
@@ -0,0 +1,46 @@
+package myvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestStringIsJSONPointer_ValidateString(t *testing.T) {
+	v := stringIsJSONPointer{}
+
+	cases := []struct {
+		name      string
+		value     string
+		wantError bool
+	}{
+		{name: "path", value: "/rules/attestations/idTokens", wantError: false},
+		{name: "wildcards", value: "/**/clientSecret", wantError: false},
+		{name: "escaped", value: "/a~1b", wantError: false},
+		{name: "no leading slash", value: "rules", wantError: true},
+		{name: "invalid escape", value: "/a~2", wantError: true},
+		{name: "empty", value: "", wantError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: basetypes.NewStringValue(tc.value),
+				Path:        path.Empty(),
+			}
+			resp := &validator.StringResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+
+			v.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("expected error: %v, got: %v", tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
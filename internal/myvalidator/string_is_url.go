@@ -0,0 +1,48 @@
+package myvalidator
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type stringIsURL struct{}
+
+func (v stringIsURL) Description(ctx context.Context) string {
+	return "validates that the string is an absolute URL using the http or https scheme"
+}
+
+func (v stringIsURL) MarkdownDescription(ctx context.Context) string {
+	return "validates that the string is an absolute URL using the `http` or `https` scheme"
+}
+
+func (stringIsURL) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	str := req.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	u, err := url.Parse(str.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			err.Error(),
+		)
+		return
+	}
+
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			"The value must be an absolute URL using the http or https scheme, for example `https://verifiedid.did.msidentity.com`.",
+		)
+	}
+}
+
+func StringIsURL() validator.String {
+	return stringIsURL{}
+}
@@ -0,0 +1,46 @@
+package myvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestStringIsURL_ValidateString(t *testing.T) {
+	v := stringIsURL{}
+
+	cases := []struct {
+		name      string
+		value     string
+		wantError bool
+	}{
+		{name: "https url", value: "https://www.contoso.com/", wantError: false},
+		{name: "https url without trailing slash", value: "https://contoso.com", wantError: false},
+		{name: "http url", value: "http://localhost:8080", wantError: false},
+		{name: "other scheme", value: "ftp://www.contoso.com/", wantError: true},
+		{name: "relative url", value: "www.contoso.com", wantError: true},
+		{name: "empty", value: "", wantError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: basetypes.NewStringValue(tc.value),
+				Path:        path.Empty(),
+			}
+			resp := &validator.StringResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+
+			v.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Errorf("expected error: %v, got: %v", tc.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
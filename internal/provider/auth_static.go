@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// staticTokenLifetime is the lifetime assumed for an access token which isn't a JWT or has no `exp` claim.
+const staticTokenLifetime = time.Hour
+
+// StaticTokenCredential returns an access token minted outside of the provider, either the token itself or the
+// content of a file. The file is read again every time the token is refreshed, so an external broker can rotate it.
+type StaticTokenCredential struct {
+	token         string
+	tokenFilePath string
+	scope         string
+}
+
+type StaticTokenCredentialOptions struct {
+	Token         string
+	TokenFilePath string
+	// Scope is the scope the token was minted for, the token isn't returned for other scopes. It is returned for any
+	// scope when Scope is empty.
+	Scope string
+}
+
+func NewStaticTokenCredential(options *StaticTokenCredentialOptions) (*StaticTokenCredential, error) {
+	token := strings.TrimSpace(options.Token)
+	if token == "" && options.TokenFilePath == "" {
+		return nil, fmt.Errorf("neither an access token nor an access token file path was supplied")
+	}
+
+	return &StaticTokenCredential{
+		token:         token,
+		tokenFilePath: options.TokenFilePath,
+		scope:         options.Scope,
+	}, nil
+}
+
+func (w *StaticTokenCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if w.scope != "" && !slices.Contains(opts.Scopes, w.scope) {
+		return azcore.AccessToken{}, fmt.Errorf("the Access Token is only used for the scope %q, another credential must be configured to obtain a token for %q", w.scope, strings.Join(opts.Scopes, " "))
+	}
+
+	token := w.token
+
+	if w.tokenFilePath != "" {
+		// #nosec G304
+		fileTokenRaw, err := os.ReadFile(w.tokenFilePath)
+		if err != nil {
+			return azcore.AccessToken{}, fmt.Errorf("reading Access Token from file %q: %v", w.tokenFilePath, err)
+		}
+
+		fileToken := strings.TrimSpace(string(fileTokenRaw))
+
+		if token != "" && token != fileToken {
+			return azcore.AccessToken{}, fmt.Errorf("mismatch between supplied Access Token and supplied Access Token file contents - please either remove one or ensure they match")
+		}
+
+		token = fileToken
+	}
+
+	if token == "" {
+		return azcore.AccessToken{}, fmt.Errorf("the Access Token file %q is empty", w.tokenFilePath)
+	}
+
+	return azcore.AccessToken{
+		Token:     token,
+		ExpiresOn: tokenExpiry(token),
+	}, nil
+}
+
+// tokenExpiry returns the expiry of the access token from its `exp` claim. The token isn't validated, its signature is
+// checked by the service.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Now().Add(staticTokenLifetime)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Now().Add(staticTokenLifetime)
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Now().Add(staticTokenLifetime)
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+)
+
+func TestStaticTokenCredential_Token(t *testing.T) {
+	cred, err := NewStaticTokenCredential(&StaticTokenCredentialOptions{Token: " opaque-token\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tk, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Token != "opaque-token" {
+		t.Fatalf("expected token %q, got %q", "opaque-token", tk.Token)
+	}
+	if tk.ExpiresOn.Before(time.Now().Add(staticTokenLifetime - time.Minute)) {
+		t.Fatalf("expected the default lifetime, got expiry %s", tk.ExpiresOn)
+	}
+}
+
+func TestStaticTokenCredential_TokenFileIsReadOnEachRefresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	expiresOn := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+	first := testJwt(expiresOn)
+	if err := os.WriteFile(path, []byte(first), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cred, err := NewStaticTokenCredential(&StaticTokenCredentialOptions{TokenFilePath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tk, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Token != first {
+		t.Fatalf("expected token %q, got %q", first, tk.Token)
+	}
+	if !tk.ExpiresOn.Equal(expiresOn) {
+		t.Fatalf("expected expiry %s, got %s", expiresOn, tk.ExpiresOn)
+	}
+
+	second := testJwt(expiresOn.Add(time.Hour))
+	if err := os.WriteFile(path, []byte(second), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if tk, err = cred.GetToken(context.Background(), policy.TokenRequestOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if tk.Token != second {
+		t.Fatalf("expected the rotated token %q, got %q", second, tk.Token)
+	}
+}
+
+func TestStaticTokenCredential_Mismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("file-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cred, err := NewStaticTokenCredential(&StaticTokenCredentialOptions{Token: "other-token", TokenFilePath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{}); err == nil {
+		t.Fatal("expected an error for mismatching tokens")
+	}
+}
+
+func TestStaticTokenCredential_Empty(t *testing.T) {
+	if _, err := NewStaticTokenCredential(&StaticTokenCredentialOptions{}); err == nil {
+		t.Fatal("expected an error when no token is supplied")
+	}
+}
+
+func TestStaticTokenCredential_Scope(t *testing.T) {
+	cred, err := NewStaticTokenCredential(&StaticTokenCredentialOptions{Token: "admin-token", Scope: clients.DefaultTokenScope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{clients.DefaultTokenScope}}); err != nil {
+		t.Fatalf("expected the token for its scope, got: %v", err)
+	}
+	if _, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{Scopes: []string{clients.DefaultRequestServiceTokenScope}}); err == nil {
+		t.Fatal("expected an error for another scope")
+	}
+}
+
+func TestAccessToken_RequestServiceClient(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	model := VerifiedIDProviderModel{
+		AccessToken: types.StringValue("admin-token"),
+		TokenScope:  types.StringValue(clients.DefaultTokenScope),
+	}
+	options := azidentity.DefaultAzureCredentialOptions{}
+	cred, err := BuildChainedTokenCredential(model, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &clients.Client{}
+	if err := client.Build(context.Background(), &clients.Option{
+		Cred:          cred,
+		OtherAPIsCred: BuildOtherAPIsTokenCredential(model, options),
+		Endpoint:      server.URL,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.VerifiedIDClient.Read(ctx, "verifiableCredentials/authorities", "v1.0", clients.RequestOptions{}); err != nil {
+		t.Fatalf("reading with the access token: %v", err)
+	}
+	_, err = client.RequestServiceClient.Action(ctx, http.MethodPost, "verifiableCredentials/createIssuanceRequest", "v1.0", map[string]interface{}{}, clients.RequestOptions{})
+	if err == nil || !strings.Contains(err.Error(), "only used for the scope") {
+		t.Errorf("expected the Request Service client to report the scope of the access token, got: %v", err)
+	}
+	if len(authorizations) != 1 || authorizations[0] != "Bearer admin-token" {
+		t.Errorf("expected the access token to be sent to the Admin API only, got %v", authorizations)
+	}
+}
+
+func testJwt(expiresOn time.Time) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return fmt.Sprintf("%s.%s.%s", encode([]byte(`{"alg":"none"}`)), encode([]byte(fmt.Sprintf(`{"exp":%d}`, expiresOn.Unix()))), encode([]byte("signature")))
+}
@@ -6,50 +6,91 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
 	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
 	"github.com/mjendza/terraform-provider-verifiedid/internal/services"
 	"github.com/mjendza/terraform-provider-verifiedid/version"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var _ provider.Provider = &VerifiedIDProvider{}
+var _ provider.ProviderWithEphemeralResources = &VerifiedIDProvider{}
 
-type VerifiedIDProvider struct{}
+type VerifiedIDProvider struct {
+	// CassettePath is the cassette the requests are recorded to, or replayed from, when `VERIFIEDID_RECORDING_MODE` is
+	// `record` or `replay`. The acceptance tests set it to a cassette per test, otherwise `VERIFIEDID_RECORDING_CASSETTE` is used.
+	CassettePath string
+
+	// Endpoint and Credential override the endpoint and the credential of the configuration, the acceptance tests set
+	// them to run against a fake server.
+	Endpoint   string
+	Credential azcore.TokenCredential
+
+	// TracerProvider records the spans of the requests, it is set from the `OTEL_*` environment variables.
+	TracerProvider trace.TracerProvider
+}
 
 type VerifiedIDProviderModel struct {
-	ClientID                     types.String `tfsdk:"client_id"`
-	ClientIDFilePath             types.String `tfsdk:"client_id_file_path"`
-	TenantID                     types.String `tfsdk:"tenant_id"`
-	ClientCertificatePath        types.String `tfsdk:"client_certificate_path"`
-	ClientCertificate            types.String `tfsdk:"client_certificate"`
-	ClientCertificatePassword    types.String `tfsdk:"client_certificate_password"`
-	ClientSecret                 types.String `tfsdk:"client_secret"`
-	ClientSecretFilePath         types.String `tfsdk:"client_secret_file_path"`
-	OIDCRequestToken             types.String `tfsdk:"oidc_request_token"`
-	OIDCRequestURL               types.String `tfsdk:"oidc_request_url"`
-	OIDCToken                    types.String `tfsdk:"oidc_token"`
-	OIDCTokenFilePath            types.String `tfsdk:"oidc_token_file_path"`
-	OIDCAzureServiceConnectionID types.String `tfsdk:"oidc_azure_service_connection_id"`
-	UseOIDC                      types.Bool   `tfsdk:"use_oidc"`
-	UseCLI                       types.Bool   `tfsdk:"use_cli"`
-	UseMSI                       types.Bool   `tfsdk:"use_msi"`
-	UseAKSWorkloadIdentity       types.Bool   `tfsdk:"use_aks_workload_identity"`
-	PartnerID                    types.String `tfsdk:"partner_id"`
-	CustomCorrelationRequestID   types.String `tfsdk:"custom_correlation_request_id"`
-	DisableCorrelationRequestID  types.Bool   `tfsdk:"disable_correlation_request_id"`
-	DisableTerraformPartnerID    types.Bool   `tfsdk:"disable_terraform_partner_id"`
+	ClientID                     types.String  `tfsdk:"client_id"`
+	ClientIDFilePath             types.String  `tfsdk:"client_id_file_path"`
+	TenantID                     types.String  `tfsdk:"tenant_id"`
+	ClientCertificatePath        types.String  `tfsdk:"client_certificate_path"`
+	ClientCertificate            types.String  `tfsdk:"client_certificate"`
+	ClientCertificatePassword    types.String  `tfsdk:"client_certificate_password"`
+	ClientSecret                 types.String  `tfsdk:"client_secret"`
+	ClientSecretFilePath         types.String  `tfsdk:"client_secret_file_path"`
+	OIDCRequestToken             types.String  `tfsdk:"oidc_request_token"`
+	OIDCRequestURL               types.String  `tfsdk:"oidc_request_url"`
+	OIDCToken                    types.String  `tfsdk:"oidc_token"`
+	OIDCTokenFilePath            types.String  `tfsdk:"oidc_token_file_path"`
+	OIDCAzureServiceConnectionID types.String  `tfsdk:"oidc_azure_service_connection_id"`
+	UseOIDC                      types.Bool    `tfsdk:"use_oidc"`
+	AccessToken                  types.String  `tfsdk:"access_token"`
+	AccessTokenFilePath          types.String  `tfsdk:"access_token_file_path"`
+	UseCLI                       types.Bool    `tfsdk:"use_cli"`
+	UseMSI                       types.Bool    `tfsdk:"use_msi"`
+	UseAKSWorkloadIdentity       types.Bool    `tfsdk:"use_aks_workload_identity"`
+	PartnerID                    types.String  `tfsdk:"partner_id"`
+	CustomCorrelationRequestID   types.String  `tfsdk:"custom_correlation_request_id"`
+	DisableCorrelationRequestID  types.Bool    `tfsdk:"disable_correlation_request_id"`
+	DisableTerraformPartnerID    types.Bool    `tfsdk:"disable_terraform_partner_id"`
+	Environment                  types.String  `tfsdk:"environment"`
+	Endpoint                     types.String  `tfsdk:"endpoint"`
+	TokenScope                   types.String  `tfsdk:"token_scope"`
+	RequestServiceTokenScope     types.String  `tfsdk:"request_service_token_scope"`
+	GraphEndpoint                types.String  `tfsdk:"graph_endpoint"`
+	GraphTokenScope              types.String  `tfsdk:"graph_token_scope"`
+	MaxConcurrentRequests        types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond            types.Float64 `tfsdk:"requests_per_second"`
+	ReadCacheTTL                 types.String  `tfsdk:"read_cache_ttl"`
+	Retry                        retry.Value   `tfsdk:"retry"`
+	TrafficLogFile               types.String  `tfsdk:"traffic_log_file"`
+	TrafficLogRedaction          types.Object  `tfsdk:"traffic_log_redaction"`
+}
+
+type TrafficLogRedactionModel struct {
+	Headers   types.List `tfsdk:"headers"`
+	JSONPaths types.List `tfsdk:"json_paths"`
 }
 
 func New() func() provider.Provider {
@@ -122,6 +163,23 @@ func (model VerifiedIDProviderModel) GetOIDCTokenFilePath() string {
 	return ""
 }
 
+// GetRedactionRules returns the redaction rules configured in addition to the default ones.
+func (model VerifiedIDProviderModel) GetRedactionRules(ctx context.Context) (clients.RedactionRules, diag.Diagnostics) {
+	rules := clients.RedactionRules{}
+	if model.TrafficLogRedaction.IsNull() || model.TrafficLogRedaction.IsUnknown() {
+		return rules, nil
+	}
+
+	var redaction TrafficLogRedactionModel
+	diags := model.TrafficLogRedaction.As(ctx, &redaction, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return rules, diags
+	}
+	diags.Append(redaction.Headers.ElementsAs(ctx, &rules.Headers, false)...)
+	diags.Append(redaction.JSONPaths.ElementsAs(ctx, &rules.JSONPaths, false)...)
+	return rules, diags
+}
+
 func (p *VerifiedIDProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "verifiedid"
 }
@@ -203,6 +261,18 @@ func (p *VerifiedIDProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				MarkdownDescription: "Should OIDC be used for Authentication? This can also be sourced from the `ARM_USE_OIDC` Environment Variable. Defaults to `false`.",
 			},
 
+			// Access Token specific fields
+			"access_token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "An access token for the Verified ID Admin API minted outside of the provider, for example by an external token broker. It's used before any other credential, for the `token_scope` only, so Microsoft Graph and the Request Service API are called with the other credentials. This can also be sourced from the `ARM_ACCESS_TOKEN` Environment Variable.",
+			},
+
+			"access_token_file_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path to a file containing an access token for the Verified ID Admin API. The file is read again every time the token is refreshed, so it can be rotated while Terraform runs. This can also be sourced from the `ARM_ACCESS_TOKEN_FILE_PATH` Environment Variable.",
+			},
+
 			// Azure CLI specific fields
 			"use_cli": schema.BoolAttribute{
 				Optional:            true,
@@ -243,6 +313,114 @@ func (p *VerifiedIDProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Optional:            true,
 				MarkdownDescription: "Disable sending the Terraform Partner ID if a custom `partner_id` isn't specified, which allows Microsoft to better understand the usage of Terraform. The Partner ID does not give HashiCorp any direct access to usage information. This can also be sourced from the `ARM_DISABLE_TERRAFORM_PARTNER_ID` environment variable. Defaults to `false`.",
 			},
+
+			// Endpoint specific fields
+			"environment": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(clients.Environments...),
+				},
+				MarkdownDescription: "The Cloud Environment which should be used, it determines the authority host used to obtain access tokens. Possible values are `public`, `usgovernment` and `china`. This can also be sourced from the `ARM_ENVIRONMENT` Environment Variable. Defaults to `public`.",
+			},
+
+			"endpoint": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsURL(),
+				},
+				MarkdownDescription: "The host of the Verified ID Admin API, for example to target a regional endpoint or a local stand-in server. This can also be sourced from the `ARM_VERIFIEDID_ENDPOINT` Environment Variable. Defaults to `https://verifiedid.did.msidentity.com`.",
+			},
+
+			"token_scope": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				MarkdownDescription: "The scope of the access tokens used to call the Verified ID Admin API. This can also be sourced from the `ARM_VERIFIEDID_TOKEN_SCOPE` Environment Variable. Defaults to `6a8b4b39-c021-437c-b060-5a14a3fd65f3/.default`.",
+			},
+
+			"request_service_token_scope": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				MarkdownDescription: "The scope of the access tokens used to call the Request Service API, which creates the issuance and presentation requests and is served by the `endpoint`. This can also be sourced from the `ARM_VERIFIEDID_REQUEST_SERVICE_TOKEN_SCOPE` Environment Variable. Defaults to `3db474b9-6a0c-4840-96ac-1fceb342124f/.default`.",
+			},
+
+			"graph_endpoint": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsURL(),
+				},
+				MarkdownDescription: "The host of Microsoft Graph, called by the resources and data sources whose `target_api` is `graph`. This can also be sourced from the `ARM_VERIFIEDID_GRAPH_ENDPOINT` Environment Variable. Defaults to the Microsoft Graph host of the `environment`, `https://graph.microsoft.com` in the public cloud.",
+			},
+
+			"graph_token_scope": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				MarkdownDescription: "The scope of the access tokens used to call Microsoft Graph. This can also be sourced from the `ARM_VERIFIEDID_GRAPH_TOKEN_SCOPE` Environment Variable. Defaults to the `.default` scope of the Microsoft Graph host of the `environment`, `https://graph.microsoft.com/.default` in the public cloud.",
+			},
+
+			// Throttling specific fields
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				MarkdownDescription: "The maximum number of requests the provider sends to a host at the same time, across all resources and data sources. The Admin API and the Request Service API share the `endpoint` and its limit, Microsoft Graph has its own. This can also be sourced from the `ARM_VERIFIEDID_MAX_CONCURRENT_REQUESTS` Environment Variable. Defaults to no limit.",
+			},
+
+			"requests_per_second": schema.Float64Attribute{
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.1),
+				},
+				MarkdownDescription: "The maximum number of requests per second the provider sends to a host, across all resources and data sources. Whatever the limits, all requests to the host are paused when it reports throttling, for the time given by the `Retry-After` header. This can also be sourced from the `ARM_VERIFIEDID_REQUESTS_PER_SECOND` Environment Variable. Defaults to no limit.",
+			},
+
+			"read_cache_ttl": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsDuration(),
+				},
+				MarkdownDescription: "How long the responses of the reads are cached, such as `30s`. The resources and data sources reading the same entity with the same query and headers during a plan or an apply then share a single request, and any create, update, delete or action on the entity, one of its parents or one of its children drops its cached responses. Keep it short, the changes made outside Terraform aren't seen until the responses expire. This can also be sourced from the `ARM_VERIFIEDID_READ_CACHE_TTL` Environment Variable. Defaults to no cache.",
+			},
+
+			"retry": retry.ProviderSchema(ctx),
+
+			// Debugging specific fields
+			"traffic_log_file": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				MarkdownDescription: "The path of an HTTP Archive (HAR 1.2) file the requests and responses of the provider are written to, with their timings, headers and bodies. The values matching the `traffic_log_redaction` rules are redacted. The traffic of every provider process of a run is appended to the file, which is written at most a second after each request and when the provider stops. Remove the file to start a new one. This can also be sourced from the `ARM_VERIFIEDID_TRAFFIC_LOG_FILE` Environment Variable.",
+			},
+
+			"traffic_log_redaction": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"headers": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+						MarkdownDescription: "The names of the headers whose values are redacted, they're case-insensitive.",
+					},
+					"json_paths": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(myvalidator.StringIsJSONPointer()),
+						},
+						MarkdownDescription: "JSON pointers to the fields of the request and response bodies whose values are redacted, such as `/rules/attestations/idTokens/*/configuration`. A `*` matches any member or element, a `**` any number of levels, and the member names are case-insensitive.",
+					},
+				},
+				MarkdownDescription: "The redaction rules applied to the requests and responses written to the debug log, the `traffic_log_file` and the recording cassettes, in addition to the default ones. The defaults redact the `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` headers, the tokens, client secrets and passwords at any level of the bodies, and the `claims` and `pin` value of the issuance requests.",
+			},
 		},
 	}
 }
@@ -359,6 +537,18 @@ func (p *VerifiedIDProvider) Configure(ctx context.Context, req provider.Configu
 		}
 	}
 
+	if model.AccessToken.IsNull() {
+		if v := os.Getenv("ARM_ACCESS_TOKEN"); v != "" {
+			model.AccessToken = types.StringValue(v)
+		}
+	}
+
+	if model.AccessTokenFilePath.IsNull() {
+		if v := os.Getenv("ARM_ACCESS_TOKEN_FILE_PATH"); v != "" {
+			model.AccessTokenFilePath = types.StringValue(v)
+		}
+	}
+
 	if model.UseCLI.IsNull() {
 		if v := os.Getenv("ARM_USE_CLI"); v != "" {
 			model.UseCLI = types.BoolValue(v == "true")
@@ -403,23 +593,165 @@ func (p *VerifiedIDProvider) Configure(ctx context.Context, req provider.Configu
 		}
 	}
 
-	option := azidentity.DefaultAzureCredentialOptions{
-		TenantID: model.TenantID.ValueString(),
+	if model.Environment.IsNull() {
+		if v := os.Getenv("ARM_ENVIRONMENT"); v != "" {
+			model.Environment = types.StringValue(v)
+		} else {
+			model.Environment = types.StringValue(clients.DefaultEnvironment)
+		}
+	}
+
+	if p.Endpoint != "" {
+		model.Endpoint = types.StringValue(p.Endpoint)
+	}
+	if model.Endpoint.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_ENDPOINT"); v != "" {
+			model.Endpoint = types.StringValue(v)
+		} else {
+			model.Endpoint = types.StringValue(clients.DefaultEndpoint)
+		}
+	}
+
+	if model.TokenScope.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_TOKEN_SCOPE"); v != "" {
+			model.TokenScope = types.StringValue(v)
+		} else {
+			model.TokenScope = types.StringValue(clients.DefaultTokenScope)
+		}
 	}
 
-	cred, err := BuildChainedTokenCredential(model, option)
+	if model.RequestServiceTokenScope.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_REQUEST_SERVICE_TOKEN_SCOPE"); v != "" {
+			model.RequestServiceTokenScope = types.StringValue(v)
+		} else {
+			model.RequestServiceTokenScope = types.StringValue(clients.DefaultRequestServiceTokenScope)
+		}
+	}
+
+	if model.GraphEndpoint.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_GRAPH_ENDPOINT"); v != "" {
+			model.GraphEndpoint = types.StringValue(v)
+		} else {
+			model.GraphEndpoint = types.StringValue(clients.GraphEndpoint(model.Environment.ValueString()))
+		}
+	}
+
+	if model.GraphTokenScope.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_GRAPH_TOKEN_SCOPE"); v != "" {
+			model.GraphTokenScope = types.StringValue(v)
+		} else {
+			model.GraphTokenScope = types.StringValue(clients.GraphTokenScope(model.Environment.ValueString()))
+		}
+	}
+
+	if model.MaxConcurrentRequests.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_MAX_CONCURRENT_REQUESTS"); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 1 {
+				resp.Diagnostics.AddError("Invalid `ARM_VERIFIEDID_MAX_CONCURRENT_REQUESTS` value", fmt.Sprintf("%q is not a positive integer", v))
+				return
+			}
+			model.MaxConcurrentRequests = types.Int64Value(n)
+		}
+	}
+
+	if model.RequestsPerSecond.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_REQUESTS_PER_SECOND"); v != "" {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil || n < 0.1 {
+				resp.Diagnostics.AddError("Invalid `ARM_VERIFIEDID_REQUESTS_PER_SECOND` value", fmt.Sprintf("%q is not a number of at least 0.1", v))
+				return
+			}
+			model.RequestsPerSecond = types.Float64Value(n)
+		}
+	}
+
+	if model.ReadCacheTTL.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_READ_CACHE_TTL"); v != "" {
+			model.ReadCacheTTL = types.StringValue(v)
+		}
+	}
+	var readCacheTTL time.Duration
+	if v := model.ReadCacheTTL.ValueString(); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			resp.Diagnostics.AddError("Invalid `read_cache_ttl` value", fmt.Sprintf("%q is not a positive duration", v))
+			return
+		}
+		readCacheTTL = d
+	}
+
+	if model.TrafficLogFile.IsNull() {
+		if v := os.Getenv("ARM_VERIFIEDID_TRAFFIC_LOG_FILE"); v != "" {
+			model.TrafficLogFile = types.StringValue(v)
+		}
+	}
+
+	redactionRules, diags := model.GetRedactionRules(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordingMode, err := clients.ParseRecordingMode(os.Getenv("VERIFIEDID_RECORDING_MODE"))
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to obtain a credential.", err.Error())
+		resp.Diagnostics.AddError("Invalid `VERIFIEDID_RECORDING_MODE` value", err.Error())
+		return
+	}
+	cassettePath := p.CassettePath
+	if cassettePath == "" {
+		cassettePath = os.Getenv("VERIFIEDID_RECORDING_CASSETTE")
+	}
+	if recordingMode != clients.RecordingModeLive && cassettePath == "" {
+		resp.Diagnostics.AddError("Missing cassette", fmt.Sprintf("`VERIFIEDID_RECORDING_CASSETTE` must be set when `VERIFIEDID_RECORDING_MODE` is %q.", recordingMode))
 		return
 	}
 
+	cloudCfg, err := clients.CloudConfiguration(model.Environment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid `environment` value", err.Error())
+		return
+	}
+
+	option := azidentity.DefaultAzureCredentialOptions{
+		ClientOptions: azcore.ClientOptions{
+			Cloud: cloudCfg,
+		},
+		TenantID: model.TenantID.ValueString(),
+	}
+
+	var cred azcore.TokenCredential = p.Credential
+	var otherAPIsCred azcore.TokenCredential
+	if cred == nil {
+		if cred, err = BuildChainedTokenCredential(model, option); err != nil {
+			resp.Diagnostics.AddError("Failed to obtain a credential.", err.Error())
+			return
+		}
+		otherAPIsCred = BuildOtherAPIsTokenCredential(model, option)
+	}
+
 	copt := &clients.Option{
 		Cred:                        cred,
+		OtherAPIsCred:               otherAPIsCred,
 		ApplicationUserAgent:        buildUserAgent(req.TerraformVersion, model.PartnerID.ValueString(), model.DisableTerraformPartnerID.ValueBool()),
 		DisableCorrelationRequestID: model.DisableCorrelationRequestID.ValueBool(),
 		CustomCorrelationRequestID:  model.CustomCorrelationRequestID.ValueString(),
-		CloudCfg:                    cloud.Configuration{},
+		CloudCfg:                    cloudCfg,
 		TenantId:                    model.TenantID.ValueString(),
+		Endpoint:                    model.Endpoint.ValueString(),
+		TokenScope:                  model.TokenScope.ValueString(),
+		RequestServiceTokenScope:    model.RequestServiceTokenScope.ValueString(),
+		GraphEndpoint:               model.GraphEndpoint.ValueString(),
+		GraphTokenScope:             model.GraphTokenScope.ValueString(),
+		RecordingMode:               recordingMode,
+		CassettePath:                cassettePath,
+		MaxConcurrentRequests:       int(model.MaxConcurrentRequests.ValueInt64()),
+		RequestsPerSecond:           model.RequestsPerSecond.ValueFloat64(),
+		DefaultRetry:                model.Retry,
+		ReadCacheTTL:                readCacheTTL,
+		TrafficLogFile:              model.TrafficLogFile.ValueString(),
+		Redaction:                   redactionRules,
+		TracerProvider:              p.TracerProvider,
 	}
 	client := &clients.Client{}
 	if err = client.Build(ctx, copt); err != nil {
@@ -429,6 +761,7 @@ func (p *VerifiedIDProvider) Configure(ctx context.Context, req provider.Configu
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *VerifiedIDProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -437,6 +770,12 @@ func (p *VerifiedIDProvider) Resources(ctx context.Context) []func() resource.Re
 		services.NewVerifiedIDResourceAction,
 		services.NewVerifiedIDUpdateResource,
 		services.NewVerifiedIDResourceCollection,
+		services.NewVerifiedIDAuthorityResource,
+		services.NewVerifiedIDContractResource,
+		services.NewVerifiedIDLinkedDomainValidationResource,
+		services.NewVerifiedIDAuthorityKeyRotationResource,
+		services.NewVerifiedIDCredentialRevocationResource,
+		services.NewVerifiedIDTenantOnboardingResource,
 	}
 }
 
@@ -444,6 +783,18 @@ func (p *VerifiedIDProvider) DataSources(ctx context.Context) []func() datasourc
 	return []func() datasource.DataSource{
 		services.NewVerifiedIDDataSource,
 		services.NewVerifiedIDResourceActionDataSource,
+		services.NewVerifiedIDAuthoritiesDataSource,
+		services.NewVerifiedIDContractsDataSource,
+		services.NewVerifiedIDDidDocumentDataSource,
+		services.NewVerifiedIDDidConfigurationDataSource,
+		services.NewVerifiedIDCredentialsDataSource,
+		services.NewVerifiedIDTenantDataSource,
+	}
+}
+
+func (p *VerifiedIDProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		services.NewVerifiedIDIssuanceRequestEphemeralResource,
 	}
 }
 
@@ -477,9 +828,38 @@ func buildUserAgent(terraformVersion string, partnerID string, disableTerraformP
 }
 
 func BuildChainedTokenCredential(model VerifiedIDProviderModel, options azidentity.DefaultAzureCredentialOptions) (*azidentity.ChainedTokenCredential, error) {
+	return buildChainedTokenCredential(model, options, true)
+}
+
+// BuildOtherAPIsTokenCredential returns the credential of the Microsoft Graph and Request Service clients, which is the
+// chain of BuildChainedTokenCredential without the static access token, minted for the Admin API only. It returns nil
+// when no access token is configured or no other credential is, the clients then use the Admin API credential, whose
+// static access token reports the scope it is limited to.
+func BuildOtherAPIsTokenCredential(model VerifiedIDProviderModel, options azidentity.DefaultAzureCredentialOptions) azcore.TokenCredential {
+	if model.AccessToken.ValueString() == "" && model.AccessTokenFilePath.ValueString() == "" {
+		return nil
+	}
+	cred, err := buildChainedTokenCredential(model, options, false)
+	if err != nil {
+		log.Printf("[DEBUG] no credential other than the static access token: %v", err)
+		return nil
+	}
+	return cred
+}
+
+func buildChainedTokenCredential(model VerifiedIDProviderModel, options azidentity.DefaultAzureCredentialOptions, includeStaticToken bool) (*azidentity.ChainedTokenCredential, error) {
 	log.Printf("[DEBUG] building chained token credential")
 	var creds []azcore.TokenCredential
 
+	if includeStaticToken && (model.AccessToken.ValueString() != "" || model.AccessTokenFilePath.ValueString() != "") {
+		log.Printf("[DEBUG] static access token credential enabled")
+		if cred, err := buildStaticTokenCredential(model); err == nil {
+			creds = append(creds, cred)
+		} else {
+			log.Printf("[DEBUG] failed to initialize static access token credential: %v", err)
+		}
+	}
+
 	if model.UseOIDC.ValueBool() || model.UseAKSWorkloadIdentity.ValueBool() {
 		log.Printf("[DEBUG] oidc credential or AKS Workload Identity enabled")
 		if cred, err := buildOidcCredential(model, options); err == nil {
@@ -533,6 +913,15 @@ func BuildChainedTokenCredential(model VerifiedIDProviderModel, options azidenti
 	return azidentity.NewChainedTokenCredential(creds, nil)
 }
 
+func buildStaticTokenCredential(model VerifiedIDProviderModel) (azcore.TokenCredential, error) {
+	o := &StaticTokenCredentialOptions{
+		Token:         model.AccessToken.ValueString(),
+		TokenFilePath: model.AccessTokenFilePath.ValueString(),
+		Scope:         model.TokenScope.ValueString(),
+	}
+	return NewStaticTokenCredential(o)
+}
+
 func buildClientSecretCredential(model VerifiedIDProviderModel, options azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
 	log.Printf("[DEBUG] building client secret credential")
 	clientID, err := model.GetClientId()
@@ -634,6 +1023,7 @@ func buildManagedIdentityCredential(model VerifiedIDProviderModel, options azide
 
 func buildAzureCLICredential(options azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
 	log.Printf("[DEBUG] building azure cli credential")
+	// The Azure CLI uses the cloud it is logged in to, the authority host can't be overridden.
 	o := &azidentity.AzureCLICredentialOptions{
 		AdditionallyAllowedTenants: options.AdditionallyAllowedTenants,
 		TenantID:                   options.TenantID,
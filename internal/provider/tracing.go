@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewProviderServer returns the factory of the provider servers. When tp isn't nil, the provider records a span for
+// each Terraform operation with, as children, the spans of the requests sent during the operation.
+func NewProviderServer(tp trace.TracerProvider) func() tfprotov6.ProviderServer {
+	return func() tfprotov6.ProviderServer {
+		server := providerserver.NewProtocol6(&VerifiedIDProvider{TracerProvider: tp})()
+		if tp == nil {
+			return server
+		}
+		return &tracingProviderServer{
+			ProviderServer: server,
+			tracer:         tp.Tracer(clients.TracerName),
+		}
+	}
+}
+
+// tracingProviderServer records the operations Terraform asks the provider to run on the resources and data sources.
+// Terraform doesn't send the address of the resources, the spans are identified by the type and the operation.
+type tracingProviderServer struct {
+	tfprotov6.ProviderServer
+	tracer trace.Tracer
+}
+
+func (s *tracingProviderServer) start(ctx context.Context, typeName string, operation string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, typeName+" "+operation, trace.WithAttributes(
+		attribute.String("terraform.resource_type", typeName),
+		attribute.String("terraform.operation", operation),
+	))
+}
+
+// end ends the span, with an error status when the operation returns an error or error diagnostics.
+func end(span trace.Span, diagnostics []*tfprotov6.Diagnostic, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	for _, d := range diagnostics {
+		if d != nil && d.Severity == tfprotov6.DiagnosticSeverityError {
+			span.SetStatus(codes.Error, d.Summary)
+			break
+		}
+	}
+	span.End()
+}
+
+func (s *tracingProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	ctx, span := s.start(ctx, "provider", "configure")
+	resp, err := s.ProviderServer.ConfigureProvider(ctx, req)
+	if resp != nil {
+		end(span, resp.Diagnostics, err)
+	} else {
+		end(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *tracingProviderServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	ctx, span := s.start(ctx, req.TypeName, "read")
+	resp, err := s.ProviderServer.ReadResource(ctx, req)
+	if resp != nil {
+		end(span, resp.Diagnostics, err)
+	} else {
+		end(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *tracingProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	ctx, span := s.start(ctx, req.TypeName, "plan")
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if resp != nil {
+		end(span, resp.Diagnostics, err)
+	} else {
+		end(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *tracingProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	ctx, span := s.start(ctx, req.TypeName, applyOperation(req.PriorState, req.PlannedState))
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		end(span, resp.Diagnostics, err)
+	} else {
+		end(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *tracingProviderServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	ctx, span := s.start(ctx, req.TypeName, "import")
+	resp, err := s.ProviderServer.ImportResourceState(ctx, req)
+	if resp != nil {
+		end(span, resp.Diagnostics, err)
+	} else {
+		end(span, nil, err)
+	}
+	return resp, err
+}
+
+func (s *tracingProviderServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	ctx, span := s.start(ctx, req.TypeName, "read")
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	if resp != nil {
+		end(span, resp.Diagnostics, err)
+	} else {
+		end(span, nil, err)
+	}
+	return resp, err
+}
+
+// applyOperation returns the CRUD operation of an apply, Terraform creates a resource without prior state and deletes
+// it with a null planned state.
+func applyOperation(priorState *tfprotov6.DynamicValue, plannedState *tfprotov6.DynamicValue) string {
+	switch {
+	case isNullDynamicValue(priorState):
+		return "create"
+	case isNullDynamicValue(plannedState):
+		return "delete"
+	default:
+		return "update"
+	}
+}
+
+// isNullDynamicValue returns whether the value is missing or null, which Terraform encodes as the MessagePack nil.
+func isNullDynamicValue(v *tfprotov6.DynamicValue) bool {
+	if v == nil {
+		return true
+	}
+	if len(v.MsgPack) > 0 {
+		return bytes.Equal(v.MsgPack, []byte{0xc0})
+	}
+	if len(v.JSON) > 0 {
+		return string(bytes.TrimSpace(v.JSON)) == "null"
+	}
+	return true
+}
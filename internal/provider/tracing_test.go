@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestApplyOperation(t *testing.T) {
+	state := &tfprotov6.DynamicValue{MsgPack: []byte{0x81, 0xa2, 'i', 'd', 0xa1, '1'}}
+	null := &tfprotov6.DynamicValue{MsgPack: []byte{0xc0}}
+
+	testcases := []struct {
+		name         string
+		priorState   *tfprotov6.DynamicValue
+		plannedState *tfprotov6.DynamicValue
+		expected     string
+	}{
+		{name: "no prior state", priorState: nil, plannedState: state, expected: "create"},
+		{name: "null prior state", priorState: null, plannedState: state, expected: "create"},
+		{name: "null planned state", priorState: state, plannedState: null, expected: "delete"},
+		{name: "json null planned state", priorState: state, plannedState: &tfprotov6.DynamicValue{JSON: []byte("null")}, expected: "delete"},
+		{name: "both states", priorState: state, plannedState: state, expected: "update"},
+	}
+	for _, tc := range testcases {
+		if actual := applyOperation(tc.priorState, tc.plannedState); actual != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.expected, actual)
+		}
+	}
+}
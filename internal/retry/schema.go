@@ -3,19 +3,31 @@ package retry
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
 )
 
+const (
+	OperationCreate = "create"
+	OperationRead   = "read"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Operations are the operations a retry block can be scoped to, the data sources only read.
+var Operations = []string{OperationCreate, OperationRead, OperationUpdate, OperationDelete}
+
 func Schema(ctx context.Context) schema.Attribute {
 	return schema.SingleNestedAttribute{
 		Attributes: map[string]schema.Attribute{
 			"error_message_regex": schema.ListAttribute{
 				ElementType:         types.StringType,
-				Required:            true,
+				Optional:            true,
 				Description:         "A list of regular expressions to match against error messages. If any of the regular expressions match, the request will be retried.",
 				MarkdownDescription: "A list of regular expressions to match against error messages. If any of the regular expressions match, the request will be retried.",
 				Validators: []validator.List{
@@ -24,14 +36,76 @@ func Schema(ctx context.Context) schema.Attribute {
 					listvalidator.SizeAtLeast(1),
 				},
 			},
+			"status_codes": schema.ListAttribute{
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Description:         "A list of HTTP status codes to retry. Defaults to 408, 429, 500, 502, 503 and 504.",
+				MarkdownDescription: "A list of HTTP status codes to retry. Defaults to `408`, `429`, `500`, `502`, `503` and `504`.",
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+					listvalidator.UniqueValues(),
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"max_attempts": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "The maximum number of attempts of a request, including the first one. Defaults to retrying until the timeout of the operation.",
+				MarkdownDescription: "The maximum number of attempts of a request, including the first one. Defaults to retrying until the timeout of the operation.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"min_delay": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The delay before the first retry, such as 2s. The delay doubles with every retry up to max_delay, unless the response has a Retry-After header. Defaults to 800ms.",
+				MarkdownDescription: "The delay before the first retry, such as `2s`. The delay doubles with every retry up to `max_delay`, unless the response has a `Retry-After` header. Defaults to `800ms`.",
+				Validators: []validator.String{
+					myvalidator.StringIsDuration(),
+				},
+			},
+			"max_delay": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The maximum delay between two attempts, such as 1m. Defaults to 60s.",
+				MarkdownDescription: "The maximum delay between two attempts, such as `1m`. Defaults to `60s`.",
+				Validators: []validator.String{
+					myvalidator.StringIsDuration(),
+				},
+			},
+			"jitter": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Whether the delays between the attempts are randomized, so parallel requests don't retry at the same time. Defaults to true.",
+				MarkdownDescription: "Whether the delays between the attempts are randomized, so parallel requests don't retry at the same time. Defaults to `true`.",
+			},
+			"operations": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Description:         "The operations the retry applies to, possible values are create, read, update and delete. Defaults to all operations.",
+				MarkdownDescription: "The operations the retry applies to, possible values are `create`, `read`, `update` and `delete`. Defaults to all operations.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(Operations...)),
+					listvalidator.UniqueValues(),
+					listvalidator.SizeAtLeast(1),
+				},
+			},
 		},
 		CustomType: Type{
 			ObjectType: types.ObjectType{
 				AttrTypes: Value{}.AttributeTypes(ctx),
 			},
 		},
+		Validators: []validator.Object{
+			delayRangeValidator{},
+		},
 		Optional:            true,
 		Description:         "The retry object supports the following attributes:",
 		MarkdownDescription: "The retry object supports the following attributes:",
 	}
 }
+
+// ProviderSchema is the retry block of the provider, the default of the resources and data sources without one.
+func ProviderSchema(ctx context.Context) schema.Attribute {
+	attribute := Schema(ctx).(schema.SingleNestedAttribute)
+	attribute.Description = "The default retry of the resources and data sources which don't configure a retry block of their own. The retry object supports the following attributes:"
+	attribute.MarkdownDescription = "The default `retry` of the resources and data sources which don't configure a `retry` block of their own. The retry object supports the following attributes:"
+	return attribute
+}
@@ -32,32 +32,50 @@ func (t Type) String() string {
 func (t Type) ValueFromObject(ctx context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	attributes := in.Attributes()
+	v := valueFromAttributes(in.Attributes(), &diags)
 
-	errorMessageRegexAttribute, ok := attributes["error_message_regex"]
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return v, diags
+}
+
+// valueFromAttributes returns a known Value from the attributes of the object, adding an error for each attribute
+// which is missing or has the wrong type.
+func valueFromAttributes(attributes map[string]attr.Value, diags *diag.Diagnostics) Value {
+	return Value{
+		ErrorMessageRegex: attributeValue[basetypes.ListValue](attributes, "error_message_regex", diags),
+		StatusCodes:       attributeValue[basetypes.ListValue](attributes, "status_codes", diags),
+		MaxAttempts:       attributeValue[basetypes.Int64Value](attributes, "max_attempts", diags),
+		MinDelay:          attributeValue[basetypes.StringValue](attributes, "min_delay", diags),
+		MaxDelay:          attributeValue[basetypes.StringValue](attributes, "max_delay", diags),
+		Jitter:            attributeValue[basetypes.BoolValue](attributes, "jitter", diags),
+		Operations:        attributeValue[basetypes.ListValue](attributes, "operations", diags),
+		state:             attr.ValueStateKnown,
+	}
+}
+
+func attributeValue[T attr.Value](attributes map[string]attr.Value, name string, diags *diag.Diagnostics) T {
+	var out T
+
+	attribute, ok := attributes[name]
 
 	if !ok {
 		diags.AddError(
 			"Attribute Missing",
-			`error_message_regex is missing from object`)
+			fmt.Sprintf(`%s is missing from object`, name))
 
-		return nil, diags
+		return out
 	}
 
-	errorMessageRegexVal, ok := errorMessageRegexAttribute.(basetypes.ListValue)
+	out, ok = attribute.(T)
 
 	if !ok {
 		diags.AddError(
 			"Attribute Wrong Type",
-			fmt.Sprintf(`error_message_regex expected to be basetypes.ListValue, was: %T`, errorMessageRegexAttribute))
+			fmt.Sprintf(`%s expected to be %T, was: %T`, name, out, attribute))
 	}
 
-	if diags.HasError() {
-		return nil, diags
-	}
-
-	return Value{
-		ErrorMessageRegex: errorMessageRegexVal,
-		state:             attr.ValueStateKnown,
-	}, diags
+	return out
 }
@@ -0,0 +1,40 @@
+package retry
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// delayRangeValidator checks that the min_delay of a retry object isn't greater than its max_delay.
+type delayRangeValidator struct{}
+
+func (v delayRangeValidator) Description(ctx context.Context) string {
+	return "validates that min_delay is not greater than max_delay"
+}
+
+func (v delayRangeValidator) MarkdownDescription(ctx context.Context) string {
+	return "validates that `min_delay` is not greater than `max_delay`"
+}
+
+func (delayRangeValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	v := valueFromAttributes(req.ConfigValue.Attributes(), &diags)
+	if diags.HasError() || v.MinDelay.IsUnknown() || v.MaxDelay.IsUnknown() {
+		return
+	}
+
+	minDelay, maxDelay := v.GetMinDelay(), v.GetMaxDelay()
+	if minDelay > 0 && maxDelay > 0 && minDelay > maxDelay {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("min_delay"),
+			"Invalid retry delays",
+			"`min_delay` must not be greater than `max_delay`.",
+		)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -78,32 +79,13 @@ func NewValue(attributeTypes map[string]attr.Type, attributes map[string]attr.Va
 		return NewValueUnknown(), diags
 	}
 
-	errorMessageRegexAttribute, ok := attributes["error_message_regex"]
-
-	if !ok {
-		diags.AddError(
-			"Attribute Missing",
-			`error_message_regex is missing from object`)
-
-		return NewValueUnknown(), diags
-	}
-
-	errorMessageRegexVal, ok := errorMessageRegexAttribute.(basetypes.ListValue)
-
-	if !ok {
-		diags.AddError(
-			"Attribute Wrong Type",
-			fmt.Sprintf(`error_message_regex expected to be basetypes.ListValue, was: %T`, errorMessageRegexAttribute))
-	}
+	v := valueFromAttributes(attributes, &diags)
 
 	if diags.HasError() {
 		return NewValueUnknown(), diags
 	}
 
-	return Value{
-		ErrorMessageRegex: errorMessageRegexVal,
-		state:             attr.ValueStateKnown,
-	}, diags
+	return v, diags
 }
 
 func NewRetryValueMust(attributeTypes map[string]attr.Type, attributes map[string]attr.Value) Value {
@@ -172,32 +154,37 @@ func (t Type) ValueType(ctx context.Context) attr.Value {
 var _ basetypes.ObjectValuable = Value{}
 
 type Value struct {
-	ErrorMessageRegex basetypes.ListValue `tfsdk:"error_message_regex"`
+	ErrorMessageRegex basetypes.ListValue   `tfsdk:"error_message_regex"`
+	StatusCodes       basetypes.ListValue   `tfsdk:"status_codes"`
+	MaxAttempts       basetypes.Int64Value  `tfsdk:"max_attempts"`
+	MinDelay          basetypes.StringValue `tfsdk:"min_delay"`
+	MaxDelay          basetypes.StringValue `tfsdk:"max_delay"`
+	Jitter            basetypes.BoolValue   `tfsdk:"jitter"`
+	Operations        basetypes.ListValue   `tfsdk:"operations"`
 	state             attr.ValueState
 }
 
 func (v Value) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
-	attrTypes := make(map[string]tftypes.Type, 5)
-
-	var val tftypes.Value
-	var err error
+	attrTypes := make(map[string]tftypes.Type, 7)
 
-	attrTypes["error_message_regex"] = basetypes.ListType{
-		ElemType: types.StringType,
-	}.TerraformType(ctx)
+	for name, attributeType := range v.AttributeTypes(ctx) {
+		attrTypes[name] = attributeType.TerraformType(ctx)
+	}
 
 	objectType := tftypes.Object{AttributeTypes: attrTypes}
 
 	switch v.state {
 	case attr.ValueStateKnown:
-		vals := make(map[string]tftypes.Value, 5)
+		vals := make(map[string]tftypes.Value, 7)
 
-		val, err = v.ErrorMessageRegex.ToTerraformValue(ctx)
-		if err != nil {
-			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
-		}
+		for name, value := range v.attributes() {
+			val, err := value.ToTerraformValue(ctx)
+			if err != nil {
+				return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+			}
 
-		vals["error_message_regex"] = val
+			vals[name] = val
+		}
 
 		if err := tftypes.ValidateValue(objectType, vals); err != nil {
 			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
@@ -228,45 +215,37 @@ func (v Value) String() string {
 func (v Value) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	var errorMessageRegexVal basetypes.ListValue
-	switch {
-	case v.ErrorMessageRegex.IsUnknown():
-		errorMessageRegexVal = types.ListUnknown(types.StringType)
-	case v.ErrorMessageRegex.IsNull():
-		errorMessageRegexVal = types.ListNull(types.StringType)
-	default:
-		var d diag.Diagnostics
-		errorMessageRegexVal, d = types.ListValue(types.StringType, v.ErrorMessageRegex.Elements())
-		diags.Append(d...)
-	}
+	attributeTypes := v.AttributeTypes(ctx)
 
-	if diags.HasError() {
-		return types.ObjectUnknown(map[string]attr.Type{
-			"error_message_regex": basetypes.ListType{
-				ElemType: types.StringType,
-			},
-		}), diags
+	if v.IsNull() {
+		return types.ObjectNull(attributeTypes), diags
 	}
 
-	attributeTypes := map[string]attr.Type{
-		"error_message_regex": basetypes.ListType{
-			ElemType: types.StringType,
-		},
+	if v.IsUnknown() {
+		return types.ObjectUnknown(attributeTypes), diags
 	}
 
-	if v.IsNull() {
-		return types.ObjectNull(attributeTypes), diags
+	attributes := v.attributes()
+	for _, name := range []string{"error_message_regex", "status_codes", "operations"} {
+		list := attributes[name].(basetypes.ListValue)
+		elemType := attributeTypes[name].(basetypes.ListType).ElemType
+		switch {
+		case list.IsUnknown():
+			attributes[name] = types.ListUnknown(elemType)
+		case list.IsNull():
+			attributes[name] = types.ListNull(elemType)
+		default:
+			var d diag.Diagnostics
+			attributes[name], d = types.ListValue(elemType, list.Elements())
+			diags.Append(d...)
+		}
 	}
 
-	if v.IsUnknown() {
+	if diags.HasError() {
 		return types.ObjectUnknown(attributeTypes), diags
 	}
 
-	objVal, diags := types.ObjectValue(
-		attributeTypes,
-		map[string]attr.Value{
-			"error_message_regex": errorMessageRegexVal,
-		})
+	objVal, diags := types.ObjectValue(attributeTypes, attributes)
 
 	return objVal, diags
 }
@@ -286,8 +265,11 @@ func (v Value) Equal(o attr.Value) bool {
 		return true
 	}
 
-	if !v.ErrorMessageRegex.Equal(other.ErrorMessageRegex) {
-		return false
+	otherAttributes := other.attributes()
+	for name, value := range v.attributes() {
+		if !value.Equal(otherAttributes[name]) {
+			return false
+		}
 	}
 
 	return true
@@ -306,6 +288,28 @@ func (v Value) AttributeTypes(ctx context.Context) map[string]attr.Type {
 		"error_message_regex": basetypes.ListType{
 			ElemType: types.StringType,
 		},
+		"status_codes": basetypes.ListType{
+			ElemType: types.Int64Type,
+		},
+		"max_attempts": types.Int64Type,
+		"min_delay":    types.StringType,
+		"max_delay":    types.StringType,
+		"jitter":       types.BoolType,
+		"operations": basetypes.ListType{
+			ElemType: types.StringType,
+		},
+	}
+}
+
+func (v Value) attributes() map[string]attr.Value {
+	return map[string]attr.Value{
+		"error_message_regex": v.ErrorMessageRegex,
+		"status_codes":        v.StatusCodes,
+		"max_attempts":        v.MaxAttempts,
+		"min_delay":           v.MinDelay,
+		"max_delay":           v.MaxDelay,
+		"jitter":              v.Jitter,
+		"operations":          v.Operations,
 	}
 }
 
@@ -334,3 +338,74 @@ func (v Value) GetErrorMessagesRegex() []regexp.Regexp {
 	}
 	return res
 }
+
+// GetStatusCodes returns the status codes to retry, nil when they're not set.
+func (v Value) GetStatusCodes() []int {
+	if v.IsNull() || v.IsUnknown() || v.StatusCodes.IsNull() || v.StatusCodes.IsUnknown() {
+		return nil
+	}
+	res := make([]int, len(v.StatusCodes.Elements()))
+	for i, elem := range v.StatusCodes.Elements() {
+		res[i] = int(elem.(types.Int64).ValueInt64())
+	}
+	return res
+}
+
+// GetMaxAttempts returns the maximum number of attempts, 0 when it's not set.
+func (v Value) GetMaxAttempts() int64 {
+	if v.IsNull() || v.IsUnknown() {
+		return 0
+	}
+	return v.MaxAttempts.ValueInt64()
+}
+
+// GetMinDelay returns the delay before the first retry, 0 when it's not set.
+func (v Value) GetMinDelay() time.Duration {
+	if v.IsNull() || v.IsUnknown() {
+		return 0
+	}
+	return parseDuration(v.MinDelay)
+}
+
+// GetMaxDelay returns the maximum delay between two attempts, 0 when it's not set.
+func (v Value) GetMaxDelay() time.Duration {
+	if v.IsNull() || v.IsUnknown() {
+		return 0
+	}
+	return parseDuration(v.MaxDelay)
+}
+
+// GetJitter returns whether the delays are randomized, which is the default.
+func (v Value) GetJitter() bool {
+	if v.IsNull() || v.IsUnknown() || v.Jitter.IsNull() || v.Jitter.IsUnknown() {
+		return true
+	}
+	return v.Jitter.ValueBool()
+}
+
+// AppliesTo reports whether the retry applies to the operation, a retry without operations applies to all of them.
+func (v Value) AppliesTo(operation string) bool {
+	if v.IsNull() || v.IsUnknown() {
+		return false
+	}
+	if v.Operations.IsNull() || v.Operations.IsUnknown() {
+		return true
+	}
+	for _, elem := range v.Operations.Elements() {
+		if elem.(types.String).ValueString() == operation {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDuration(value types.String) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return 0
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return 0
+	}
+	return d
+}
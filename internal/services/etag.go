@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// etagPrivateStateKey is the key of the entity tag of a resource in its private state, it's captured each time the
+// resource is read and sent back in the `If-Match` header of the next update.
+const etagPrivateStateKey = "etag"
+
+const headerIfMatch = "If-Match"
+
+// privateStateGetter and privateStateSetter are implemented by the private state of the requests and the responses.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// readWithETag reads a resource like MSGraphClient.Read, and returns its entity tag as well. The read bypasses the
+// read cache, whose entries don't keep the headers of the responses.
+func readWithETag(ctx context.Context, client *clients.VerifiedIDClient, url string, apiVersion string, options clients.RequestOptions) (interface{}, string, error) {
+	var rawResponse *http.Response
+	responseBody, err := client.Read(policy.WithCaptureResponse(clients.WithoutReadCache(ctx), &rawResponse), url, apiVersion, options)
+	if err != nil {
+		return nil, "", err
+	}
+	return responseBody, utils.ResponseETag(rawResponse, responseBody), nil
+}
+
+// getETag returns the entity tag stored in the private state, or an empty string when there's none.
+func getETag(ctx context.Context, private privateStateGetter) (string, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, etagPrivateStateKey)
+	if diags.HasError() || len(data) == 0 {
+		return "", diags
+	}
+	var etag string
+	if err := json.Unmarshal(data, &etag); err != nil {
+		diags.AddError("Invalid private state", fmt.Sprintf("The entity tag of the resource is invalid: %s", err.Error()))
+	}
+	return etag, diags
+}
+
+// setETag stores the entity tag in the private state, an empty entity tag removes it.
+func setETag(ctx context.Context, private privateStateSetter, etag string) diag.Diagnostics {
+	if etag == "" {
+		return private.SetKey(ctx, etagPrivateStateKey, nil)
+	}
+	data, err := json.Marshal(etag)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, etagPrivateStateKey, data)
+}
+
+// ifMatchHeaders returns the headers making an update conditional on the resource being unchanged since it was last
+// read. There are none when the entity tag is unknown or the resource disables them with `disable_etag`.
+func ifMatchHeaders(etag string, disabled bool) map[string]string {
+	if etag == "" || disabled {
+		return nil
+	}
+	return map[string]string{
+		headerIfMatch: etag,
+	}
+}
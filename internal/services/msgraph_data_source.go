@@ -24,13 +24,14 @@ func NewVerifiedIDDataSource() datasource.DataSource {
 
 // VerifiedIDDataSource defines the data source implementation.
 type VerifiedIDDataSource struct {
-	client *clients.VerifiedIDClient
+	client *clients.Client
 }
 
 // VerifiedIDDataSourceModel describes the data source data model.
 type VerifiedIDDataSourceModel struct {
 	Id                   types.String      `tfsdk:"id"`
 	ApiVersion           types.String      `tfsdk:"api_version"`
+	TargetAPI            types.String      `tfsdk:"target_api"`
 	Url                  types.String      `tfsdk:"url"`
 	ResponseExportValues map[string]string `tfsdk:"response_export_values"`
 	Headers              types.Map         `tfsdk:"headers"`
@@ -68,6 +69,14 @@ func (r *VerifiedIDDataSource) Schema(ctx context.Context, req datasource.Schema
 				},
 			},
 
+			"target_api": schema.StringAttribute{
+				MarkdownDescription: docstrings.TargetAPI(),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clients.TargetAPIs...),
+				},
+			},
+
 			"response_export_values": schema.MapAttribute{
 				MarkdownDescription: docstrings.ResponseExportValues(),
 				Optional:            true,
@@ -106,7 +115,7 @@ func (r *VerifiedIDDataSource) Schema(ctx context.Context, req datasource.Schema
 
 func (r *VerifiedIDDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if v, ok := req.ProviderData.(*clients.Client); ok {
-		r.client = v.VerifiedIDClient
+		r.client = v
 	}
 }
 
@@ -126,14 +135,15 @@ func (r *VerifiedIDDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		apiVersion = model.ApiVersion.ValueString()
 	}
 
+	client := r.client.API(model.TargetAPI.ValueString())
 	options := clients.RequestOptions{
 		Headers:         AsMapOfString(model.Headers),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationRead),
 	}
-	responseBody, err := r.client.Read(ctx, model.Url.ValueString(), apiVersion, options)
+	responseBody, err := client.Read(ctx, model.Url.ValueString(), apiVersion, options)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read data source", err.Error())
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read data source", err)
 		return
 	}
 
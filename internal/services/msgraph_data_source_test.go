@@ -87,6 +87,7 @@ func (r VerifiedIDTestDataSource) basic(data acceptance.TestData) string {
 
 data "verifiedid_resource" "test" {
   url = "applications/${verifiedid_resource.test.id}"
+  target_api = "graph"
 }
 `, VerifiedIDTestResource{}.basic(data))
 }
@@ -99,6 +100,7 @@ locals {
 
 data "verifiedid_resource" "test" {
   url = "servicePrincipals"
+  target_api = "graph"
   query_parameters = {
     "$filter" = ["appId eq '${local.MicrosoftGraphAppId}'"]
   }
@@ -112,6 +114,7 @@ func (r VerifiedIDTestDataSource) list(data acceptance.TestData) string {
 	return `
 data "verifiedid_resource" "test" {
   url = "groups"
+  target_api = "graph"
   response_export_values = {
     all = "@"
   }
@@ -122,6 +125,7 @@ func (r VerifiedIDTestDataSource) withRetry(data acceptance.TestData) string {
 	return `
 data "verifiedid_resource" "test" {
   url = "groups"
+  target_api = "graph"
   retry = {
     error_message_regex = [
       "temporary error",
@@ -143,6 +147,7 @@ func (r VerifiedIDTestDataSource) dataSourceWithReadTimeout() string {
 	return `
 data "verifiedid_resource" "test" {
   url = "applications/${verifiedid_resource.test.id}"
+  target_api = "graph"
   timeouts {
     read = "1ns"
   }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -43,7 +44,7 @@ func NewVerifiedIDResource() resource.Resource {
 
 // VerifiedIDResource defines the resource implementation.
 type VerifiedIDResource struct {
-	client *clients.VerifiedIDClient
+	client *clients.Client
 }
 
 func (r *VerifiedIDResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
@@ -55,10 +56,12 @@ type VerifiedIDResourceModel struct {
 	Id                    types.String      `tfsdk:"id"`
 	ResourceUrl           types.String      `tfsdk:"resource_url"`
 	ApiVersion            types.String      `tfsdk:"api_version"`
+	TargetAPI             types.String      `tfsdk:"target_api"`
 	Url                   types.String      `tfsdk:"url"`
 	Body                  types.Dynamic     `tfsdk:"body"`
 	IgnoreMissingProperty types.Bool        `tfsdk:"ignore_missing_property"`
 	PatchAsFullBody       types.Bool        `tfsdk:"patch_as_full_body"`
+	DisableETag           types.Bool        `tfsdk:"disable_etag"`
 	CreateQueryParameters types.Map         `tfsdk:"create_query_parameters"`
 	UpdateQueryParameters types.Map         `tfsdk:"update_query_parameters"`
 	ReadQueryParameters   types.Map         `tfsdk:"read_query_parameters"`
@@ -102,6 +105,19 @@ func (r *VerifiedIDResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Default: stringdefault.StaticString("v1.0"),
 			},
 
+			"target_api": schema.StringAttribute{
+				MarkdownDescription: docstrings.TargetAPI(),
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clients.TargetAPIs...),
+				},
+				Default: stringdefault.StaticString(clients.TargetAPIVerifiedID),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
 			"body": schema.DynamicAttribute{
 				MarkdownDescription: docstrings.Body(),
 				Optional:            true,
@@ -121,6 +137,13 @@ func (r *VerifiedIDResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Default:             booldefault.StaticBool(false),
 			},
 
+			"disable_etag": schema.BoolAttribute{
+				MarkdownDescription: docstrings.DisableETag(),
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
 			"create_query_parameters": schema.MapAttribute{
 				ElementType: types.ListType{
 					ElemType: types.StringType,
@@ -183,7 +206,7 @@ func (r *VerifiedIDResource) Schema(ctx context.Context, req resource.SchemaRequ
 
 func (r *VerifiedIDResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if v, ok := req.ProviderData.(*clients.Client); ok {
-		r.client = v.VerifiedIDClient
+		r.client = v
 	}
 }
 
@@ -232,13 +255,14 @@ func (r *VerifiedIDResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	client := r.client.API(model.TargetAPI.ValueString())
 	options := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.CreateQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationCreate),
 	}
-	responseBody, err := r.client.Create(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
+	responseBody, err := client.Create(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create resource", err.Error())
+		addBodyResponseErrorDiagnostic(&resp.Diagnostics, "Failed to create resource", err)
 		return
 	}
 
@@ -272,12 +296,16 @@ func (r *VerifiedIDResource) Create(ctx context.Context, req resource.CreateRequ
 			QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
 			RetryOptions: clients.CombineRetryOptions(
 				clients.NewRetryOptionsForReadAfterCreate(),
-				clients.NewRetryOptions(model.Retry),
+				client.RetryOptions(model.Retry, retry.OperationCreate),
 			),
 		}
-		responseBody, err = r.client.Read(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
+		var etag string
+		responseBody, etag, err = readWithETag(ctx, client, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to read data source", err.Error())
+			addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read data source", err)
+			return
+		}
+		if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
 			return
 		}
 	}
@@ -329,15 +357,21 @@ func (r *VerifiedIDResource) Update(ctx context.Context, req resource.UpdateRequ
 		bodyToUpdate = utils.DiffObject(previousBody, requestBody, diffOption)
 	}
 
+	client := r.client.API(model.TargetAPI.ValueString())
 	// If there's something to update, send PATCH
 	if !utils.IsEmptyObject(bodyToUpdate) {
+		etag, diags := getETag(ctx, req.Private)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
 		options := clients.RequestOptions{
+			Headers:         ifMatchHeaders(etag, model.DisableETag.ValueBool()),
 			QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.UpdateQueryParameters)),
-			RetryOptions:    clients.NewRetryOptions(model.Retry),
+			RetryOptions:    client.RetryOptions(model.Retry, retry.OperationUpdate),
 		}
-		_, err := r.client.Update(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), bodyToUpdate, options)
+		_, err := client.Update(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), bodyToUpdate, options)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to create resource", err.Error())
+			addBodyResponseErrorDiagnostic(&resp.Diagnostics, "Failed to create resource", err)
 			return
 		}
 	} else {
@@ -346,11 +380,14 @@ func (r *VerifiedIDResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	options := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationUpdate),
 	}
-	responseBody, err := r.client.Read(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
+	responseBody, etag, err := readWithETag(ctx, client, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read data source", err.Error())
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read data source", err)
+		return
+	}
+	if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
 		return
 	}
 	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
@@ -380,15 +417,20 @@ func (r *VerifiedIDResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	client := r.client.API(model.TargetAPI.ValueString())
 	options := clients.NewRequestOptions(nil, AsMapOfLists(model.ReadQueryParameters))
-	responseBody, err := r.client.Read(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
+	options.RetryOptions = client.RetryOptions(model.Retry, retry.OperationRead)
+	responseBody, etag, err := readWithETag(ctx, client, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("Error reading %q - removing from state", model.Id.ValueString()))
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to read data source", err.Error())
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read data source", err)
+		return
+	}
+	if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
 		return
 	}
 	state.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
@@ -443,13 +485,14 @@ func (r *VerifiedIDResource) Delete(ctx context.Context, req resource.DeleteRequ
 		itemUrl = fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString())
 	}
 
+	client := r.client.API(model.TargetAPI.ValueString())
 	options := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.DeleteQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationDelete),
 	}
-	err := r.client.Delete(ctx, itemUrl, model.ApiVersion.ValueString(), options)
+	err := client.Delete(ctx, itemUrl, model.ApiVersion.ValueString(), options)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete resource", err.Error())
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to delete resource", err)
 		return
 	}
 }
@@ -467,6 +510,15 @@ func (r *VerifiedIDResource) ImportState(ctx context.Context, req resource.Impor
 		apiVersion = parsedUrl.Query().Get("api-version")
 	}
 
+	targetAPI := clients.TargetAPIVerifiedID
+	if v := parsedUrl.Query().Get("target-api"); v != "" {
+		if !slices.Contains(clients.TargetAPIs, v) {
+			resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("The target API %q is not supported, supported values are: %s", v, strings.Join(clients.TargetAPIs, ", ")))
+			return
+		}
+		targetAPI = v
+	}
+
 	if strings.HasSuffix(parsedUrl.Path, "/$ref") {
 		reqIdWithoutRef := strings.TrimSuffix(parsedUrl.Path, "/$ref")
 		lastIndex := strings.LastIndex(reqIdWithoutRef, "/")
@@ -496,8 +548,10 @@ func (r *VerifiedIDResource) ImportState(ctx context.Context, req resource.Impor
 		ResourceUrl:           types.StringValue(resourceUrl),
 		Url:                   types.StringValue(urlValue),
 		ApiVersion:            types.StringValue(apiVersion),
+		TargetAPI:             types.StringValue(targetAPI),
 		IgnoreMissingProperty: types.BoolValue(true),
 		PatchAsFullBody:       types.BoolValue(false),
+		DisableETag:           types.BoolValue(false),
 		CreateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
 		UpdateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
 		ReadQueryParameters:   types.MapNull(types.ListType{ElemType: types.StringType}),
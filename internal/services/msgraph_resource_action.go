@@ -33,13 +33,14 @@ func NewVerifiedIDResourceAction() resource.Resource {
 
 // VerifiedIDResourceAction defines the resource implementation.
 type VerifiedIDResourceAction struct {
-	client *clients.VerifiedIDClient
+	client *clients.Client
 }
 
 // VerifiedIDResourceActionModel describes the resource data model.
 type VerifiedIDResourceActionModel struct {
 	Id                   types.String      `tfsdk:"id"`
 	ApiVersion           types.String      `tfsdk:"api_version"`
+	TargetAPI            types.String      `tfsdk:"target_api"`
 	ResourceUrl          types.String      `tfsdk:"resource_url"`
 	Action               types.String      `tfsdk:"action"`
 	Method               types.String      `tfsdk:"method"`
@@ -110,6 +111,19 @@ func (r *VerifiedIDResourceAction) Schema(ctx context.Context, req resource.Sche
 				},
 			},
 
+			"target_api": schema.StringAttribute{
+				MarkdownDescription: docstrings.TargetAPI(),
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clients.TargetAPIs...),
+				},
+				Default: stringdefault.StaticString(clients.TargetAPIVerifiedID),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
 			"body": schema.DynamicAttribute{
 				MarkdownDescription: docstrings.Body(),
 				Optional:            true,
@@ -153,7 +167,7 @@ func (r *VerifiedIDResourceAction) Schema(ctx context.Context, req resource.Sche
 
 func (r *VerifiedIDResourceAction) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if v, ok := req.ProviderData.(*clients.Client); ok {
-		r.client = v.VerifiedIDClient
+		r.client = v
 	}
 }
 
@@ -190,8 +204,8 @@ func (r *VerifiedIDResourceAction) Create(ctx context.Context, req resource.Crea
 	model.Id = types.StringValue(fullUrl)
 
 	// Execute the action
-	if err := r.executeAction(ctx, model); err != nil {
-		resp.Diagnostics.AddError("Failed to execute action", err.Error())
+	if err := r.executeAction(ctx, model, retry.OperationCreate); err != nil {
+		addBodyResponseErrorDiagnostic(&resp.Diagnostics, "Failed to execute action", err)
 		return
 	}
 
@@ -211,8 +225,8 @@ func (r *VerifiedIDResourceAction) Update(ctx context.Context, req resource.Upda
 	defer cancel()
 
 	// Re-execute the action
-	if err := r.executeAction(ctx, model); err != nil {
-		resp.Diagnostics.AddError("Failed to execute action", err.Error())
+	if err := r.executeAction(ctx, model, retry.OperationUpdate); err != nil {
+		addBodyResponseErrorDiagnostic(&resp.Diagnostics, "Failed to execute action", err)
 		return
 	}
 
@@ -220,7 +234,7 @@ func (r *VerifiedIDResourceAction) Update(ctx context.Context, req resource.Upda
 }
 
 // executeAction is a helper function that performs the actual API call
-func (r *VerifiedIDResourceAction) executeAction(ctx context.Context, model *VerifiedIDResourceActionModel) error {
+func (r *VerifiedIDResourceAction) executeAction(ctx context.Context, model *VerifiedIDResourceActionModel, operation string) error {
 	// Prepare request body
 	var requestBody interface{}
 	if !model.Body.IsNull() && !model.Body.IsUnknown() {
@@ -230,10 +244,11 @@ func (r *VerifiedIDResourceAction) executeAction(ctx context.Context, model *Ver
 	}
 
 	// Prepare request options
+	client := r.client.API(model.TargetAPI.ValueString())
 	options := clients.RequestOptions{
 		Headers:         AsMapOfString(model.Headers),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, operation),
 	}
 
 	// Construct the full URL from resource_url and action
@@ -246,7 +261,7 @@ func (r *VerifiedIDResourceAction) executeAction(ctx context.Context, model *Ver
 	tflog.Info(ctx, fmt.Sprintf("Executing %s action on %s", model.Method.ValueString(), fullUrl))
 
 	// Execute the action
-	responseBody, err := r.client.Action(ctx, model.Method.ValueString(), fullUrl, model.ApiVersion.ValueString(), requestBody, options)
+	responseBody, err := client.Action(ctx, model.Method.ValueString(), fullUrl, model.ApiVersion.ValueString(), requestBody, options)
 	if err != nil {
 		return fmt.Errorf("API call failed: %w", err)
 	}
@@ -27,13 +27,14 @@ func NewVerifiedIDResourceActionDataSource() datasource.DataSource {
 
 // VerifiedIDResourceActionDataSource defines the data source implementation.
 type VerifiedIDResourceActionDataSource struct {
-	client *clients.VerifiedIDClient
+	client *clients.Client
 }
 
 // VerifiedIDResourceActionDataSourceModel describes the data source data model.
 type VerifiedIDResourceActionDataSourceModel struct {
 	Id                   types.String      `tfsdk:"id"`
 	ApiVersion           types.String      `tfsdk:"api_version"`
+	TargetAPI            types.String      `tfsdk:"target_api"`
 	ResourceUrl          types.String      `tfsdk:"resource_url"`
 	Action               types.String      `tfsdk:"action"`
 	Method               types.String      `tfsdk:"method"`
@@ -87,6 +88,14 @@ func (r *VerifiedIDResourceActionDataSource) Schema(ctx context.Context, req dat
 				},
 			},
 
+			"target_api": schema.StringAttribute{
+				MarkdownDescription: docstrings.TargetAPI(),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clients.TargetAPIs...),
+				},
+			},
+
 			"body": schema.DynamicAttribute{
 				MarkdownDescription: docstrings.Body(),
 				Optional:            true,
@@ -130,7 +139,7 @@ func (r *VerifiedIDResourceActionDataSource) Schema(ctx context.Context, req dat
 
 func (r *VerifiedIDResourceActionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if v, ok := req.ProviderData.(*clients.Client); ok {
-		r.client = v.VerifiedIDClient
+		r.client = v
 	}
 }
 
@@ -154,11 +163,12 @@ func (r *VerifiedIDResourceActionDataSource) Read(ctx context.Context, req datas
 		}
 	}
 
+	client := r.client.API(model.TargetAPI.ValueString())
 	// Prepare request options
 	options := clients.RequestOptions{
 		Headers:         AsMapOfString(model.Headers),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationRead),
 	}
 
 	// Construct the full URL from resource_url and action
@@ -183,9 +193,9 @@ func (r *VerifiedIDResourceActionDataSource) Read(ctx context.Context, req datas
 	tflog.Info(ctx, fmt.Sprintf("Executing %s action on %s", method, fullUrl))
 
 	// Execute the action
-	responseBody, err := r.client.Action(ctx, method, fullUrl, apiVersion, requestBody, options)
+	responseBody, err := client.Action(ctx, method, fullUrl, apiVersion, requestBody, options)
 	if err != nil {
-		resp.Diagnostics.AddError("API call failed", err.Error())
+		addBodyResponseErrorDiagnostic(&resp.Diagnostics, "API call failed", err)
 		return
 	}
 
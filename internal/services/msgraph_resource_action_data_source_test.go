@@ -75,6 +75,7 @@ provider "msgraph" {}
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Test Group"
     mailEnabled     = false
@@ -89,6 +90,7 @@ resource "verifiedid_resource" "group" {
 
 data "verifiedid_resource_action" "test" {
   resource_url = verifiedid_resource.group.resource_url
+  target_api = "graph"
   action       = "members"
   method       = "GET"
 }
@@ -101,6 +103,7 @@ provider "msgraph" {}
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Test Group"
     mailEnabled     = false
@@ -115,6 +118,7 @@ resource "verifiedid_resource" "group" {
 
 data "verifiedid_resource_action" "test" {
   resource_url = verifiedid_resource.group.resource_url
+  target_api = "graph"
   action       = "owners"
   method       = "GET"
 
@@ -132,6 +136,7 @@ provider "msgraph" {}
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Test Group"
     mailEnabled     = false
@@ -146,6 +151,7 @@ resource "verifiedid_resource" "group" {
 
 data "verifiedid_resource_action" "test" {
   resource_url = verifiedid_resource.group.resource_url
+  target_api = "graph"
   action       = "owners"
   method       = "GET"
 
@@ -162,6 +168,7 @@ provider "msgraph" {}
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Test Group"
     mailEnabled     = false
@@ -176,6 +183,7 @@ resource "verifiedid_resource" "group" {
 
 data "verifiedid_resource_action" "test" {
   resource_url = verifiedid_resource.group.resource_url
+  target_api = "graph"
   action       = "checkMemberObjects"
   method       = "POST"
 
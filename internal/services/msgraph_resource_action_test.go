@@ -78,6 +78,7 @@ provider "msgraph" {}
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Test Group"
     mailEnabled     = false
@@ -92,6 +93,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_action" "test" {
   resource_url = verifiedid_resource.group.resource_url
+  target_api = "graph"
   method       = "PATCH"
 
   body = {
@@ -107,6 +109,7 @@ provider "msgraph" {}
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Test Group"
     mailEnabled     = false
@@ -121,6 +124,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_action" "test" {
   resource_url = verifiedid_resource.group.resource_url
+  target_api = "graph"
   method       = "PATCH"
 
   query_parameters = {
@@ -140,6 +144,7 @@ provider "msgraph" {}
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Test Group"
     mailEnabled     = false
@@ -154,6 +159,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_action" "test" {
   resource_url = verifiedid_resource.group.resource_url
+  target_api = "graph"
   method       = "PATCH"
 
   headers = {
@@ -174,6 +180,7 @@ provider "msgraph" {}
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Test Group"
     mailEnabled     = false
@@ -188,6 +195,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_action" "test" {
   resource_url = verifiedid_resource.group.resource_url
+  target_api = "graph"
   method       = "PATCH"
 
   body = {
@@ -38,11 +38,12 @@ func NewVerifiedIDResourceCollection() resource.Resource {
 	return &VerifiedIDResourceCollection{}
 }
 
-type VerifiedIDResourceCollection struct{ client *clients.VerifiedIDClient }
+type VerifiedIDResourceCollection struct{ client *clients.Client }
 
 type VerifiedIDResourceCollectionModel struct {
 	Id                   types.String      `tfsdk:"id"`
 	ApiVersion           types.String      `tfsdk:"api_version"`
+	TargetAPI            types.String      `tfsdk:"target_api"`
 	Url                  types.String      `tfsdk:"url"`
 	ReferenceIds         types.List        `tfsdk:"reference_ids"`
 	ReadQueryParameters  types.Map         `tfsdk:"read_query_parameters"`
@@ -87,6 +88,19 @@ func (r *VerifiedIDResourceCollection) Schema(ctx context.Context, req resource.
 				Default:             stringdefault.StaticString("v1.0"),
 			},
 
+			"target_api": schema.StringAttribute{
+				MarkdownDescription: docstrings.TargetAPI(),
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clients.TargetAPIs...),
+				},
+				Default: stringdefault.StaticString(clients.TargetAPIVerifiedID),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
 			"reference_ids": schema.ListAttribute{
 				MarkdownDescription: "List of object IDs that MUST exist in this `$ref` collection. Missing IDs are added; extra remote items are removed. Order is ignored. Each value should be the GUID (or string identifier) of an existing directory object (user, group, service principal, etc.).",
 				ElementType:         types.StringType,
@@ -121,7 +135,7 @@ func (r *VerifiedIDResourceCollection) Schema(ctx context.Context, req resource.
 
 func (r *VerifiedIDResourceCollection) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if v, ok := req.ProviderData.(*clients.Client); ok {
-		r.client = v.VerifiedIDClient
+		r.client = v
 	}
 }
 
@@ -157,21 +171,22 @@ func (r *VerifiedIDResourceCollection) Create(ctx context.Context, req resource.
 	defer cancel()
 
 	newItems := AsListOfString(model.ReferenceIds)
-	if err := r.syncCollection(ctx, model, nil, newItems); err != nil {
-		resp.Diagnostics.AddError("Failed to sync collection", err.Error())
+	if err := r.syncCollection(ctx, model, nil, newItems, retry.OperationCreate); err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to sync collection", err)
 		return
 	}
 
 	model.Id = types.StringValue(baseCollectionUrl(model.Url.ValueString()))
 
 	base := baseCollectionUrl(model.Url.ValueString())
+	client := r.client.API(model.TargetAPI.ValueString())
 	opts := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationCreate),
 	}
-	body, err := r.client.List(ctx, base, model.ApiVersion.ValueString(), opts)
+	body, err := client.List(ctx, base, model.ApiVersion.ValueString(), opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read collection", err.Error())
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read collection", err)
 		return
 	}
 
@@ -195,19 +210,20 @@ func (r *VerifiedIDResourceCollection) Update(ctx context.Context, req resource.
 
 	newItems := AsListOfString(model.ReferenceIds)
 	oldItems := AsListOfString(state.ReferenceIds)
-	if err := r.syncCollection(ctx, model, oldItems, newItems); err != nil {
-		resp.Diagnostics.AddError("Failed to sync collection", err.Error())
+	if err := r.syncCollection(ctx, model, oldItems, newItems, retry.OperationUpdate); err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to sync collection", err)
 		return
 	}
 
 	base := baseCollectionUrl(model.Url.ValueString())
+	client := r.client.API(model.TargetAPI.ValueString())
 	opts := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationUpdate),
 	}
-	body, err := r.client.List(ctx, base, model.ApiVersion.ValueString(), opts)
+	body, err := client.List(ctx, base, model.ApiVersion.ValueString(), opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read collection", err.Error())
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read collection", err)
 		return
 	}
 
@@ -227,18 +243,19 @@ func (r *VerifiedIDResourceCollection) Read(ctx context.Context, req resource.Re
 	defer cancel()
 
 	base := baseCollectionUrl(model.Url.ValueString())
+	client := r.client.API(model.TargetAPI.ValueString())
 	opts := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationRead),
 	}
-	body, err := r.client.List(ctx, base, model.ApiVersion.ValueString(), opts)
+	body, err := client.List(ctx, base, model.ApiVersion.ValueString(), opts)
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, "Collection not found - removing from state")
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to read collection", err.Error())
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read collection", err)
 		return
 	}
 
@@ -264,13 +281,13 @@ func (r *VerifiedIDResourceCollection) Delete(ctx context.Context, req resource.
 	defer cancel()
 
 	oldItems := AsListOfString(model.ReferenceIds)
-	if err := r.syncCollection(ctx, model, oldItems, nil); err != nil {
-		resp.Diagnostics.AddError("Failed to sync collection", err.Error())
+	if err := r.syncCollection(ctx, model, oldItems, nil, retry.OperationDelete); err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to sync collection", err)
 		return
 	}
 }
 
-func (r *VerifiedIDResourceCollection) syncCollection(ctx context.Context, model *VerifiedIDResourceCollectionModel, oldItems []string, newItems []string) error {
+func (r *VerifiedIDResourceCollection) syncCollection(ctx context.Context, model *VerifiedIDResourceCollectionModel, oldItems []string, newItems []string, operation string) error {
 	toRemove := make([]string, 0)
 	toAdd := make([]string, 0)
 	oldSet := make(map[string]bool)
@@ -291,22 +308,23 @@ func (r *VerifiedIDResourceCollection) syncCollection(ctx context.Context, model
 			toAdd = append(toAdd, item)
 		}
 	}
-	return r.applyCollection(ctx, model, toRemove, toAdd)
+	return r.applyCollection(ctx, model, toRemove, toAdd, operation)
 }
 
-func (r *VerifiedIDResourceCollection) applyCollection(ctx context.Context, model *VerifiedIDResourceCollectionModel, toRemove []string, toAdd []string) error {
+func (r *VerifiedIDResourceCollection) applyCollection(ctx context.Context, model *VerifiedIDResourceCollectionModel, toRemove []string, toAdd []string, operation string) error {
 	errs := make([]error, 0)
+	client := r.client.API(model.TargetAPI.ValueString())
 	for _, item := range toAdd {
 		body := map[string]string{}
-		body["@odata.id"] = fmt.Sprintf("%s/%s/directoryObjects/%s", r.client.GraphBaseUrl(), model.ApiVersion.ValueString(), item)
-		_, err := r.client.Create(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), body, clients.RequestOptions{RetryOptions: clients.NewRetryOptions(model.Retry)})
+		body["@odata.id"] = fmt.Sprintf("%s/%s/directoryObjects/%s", client.GraphBaseUrl(), model.ApiVersion.ValueString(), item)
+		_, err := client.Create(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), body, clients.RequestOptions{RetryOptions: client.RetryOptions(model.Retry, operation)})
 		if err != nil {
 			errs = append(errs, err)
 		}
 	}
 	for _, item := range toRemove {
 		delUrl := fmt.Sprintf("%s/%s/$ref", baseCollectionUrl(model.Url.ValueString()), item)
-		err := r.client.Delete(ctx, delUrl, model.ApiVersion.ValueString(), clients.RequestOptions{RetryOptions: clients.NewRetryOptions(model.Retry)})
+		err := client.Delete(ctx, delUrl, model.ApiVersion.ValueString(), clients.RequestOptions{RetryOptions: client.RetryOptions(model.Retry, operation)})
 		if err != nil {
 			errs = append(errs, err)
 		}
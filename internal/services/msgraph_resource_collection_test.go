@@ -144,7 +144,7 @@ func (r VerifiedIDTestResourceCollection) Exists(ctx context.Context, client *cl
 	apiVersion := state.Attributes["api_version"]
 	id := state.Attributes["id"] // base collection URL
 
-	_, err := client.VerifiedIDClient.List(ctx, id, apiVersion, clients.DefaultRequestOptions())
+	_, err := client.API(state.Attributes["target_api"]).List(ctx, id, apiVersion, clients.DefaultRequestOptions())
 	if err == nil {
 		b := true
 		return &b, nil
@@ -161,6 +161,7 @@ func (r VerifiedIDTestResourceCollection) basic() string {
 	return `
 resource "verifiedid_resource" "application_a" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App a"
   }
@@ -171,6 +172,7 @@ resource "verifiedid_resource" "application_a" {
 
 resource "verifiedid_resource" "sp_a" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_a.output.appId
   }
@@ -178,6 +180,7 @@ resource "verifiedid_resource" "sp_a" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Collection Group"
     mailEnabled     = false
@@ -188,6 +191,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_collection" "test" {
   url           = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   api_version   = "beta"
   reference_ids = [verifiedid_resource.sp_a.id]
 }
@@ -198,6 +202,7 @@ func (r VerifiedIDTestResourceCollection) basicWithReadQueryParameters() string
 	return `
 resource "verifiedid_resource" "application_a" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App a"
   }
@@ -208,6 +213,7 @@ resource "verifiedid_resource" "application_a" {
 
 resource "verifiedid_resource" "sp_a" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_a.output.appId
   }
@@ -215,6 +221,7 @@ resource "verifiedid_resource" "sp_a" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Collection Group"
     mailEnabled     = false
@@ -225,6 +232,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_collection" "test" {
   url           = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   api_version   = "beta"
   reference_ids = [verifiedid_resource.sp_a.id]
   read_query_parameters = {
@@ -238,6 +246,7 @@ func (r VerifiedIDTestResourceCollection) updateOneMember() string {
 	return `
 resource "verifiedid_resource" "application_a" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App a"
   }
@@ -248,6 +257,7 @@ resource "verifiedid_resource" "application_a" {
 
 resource "verifiedid_resource" "sp_a" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_a.output.appId
   }
@@ -255,6 +265,7 @@ resource "verifiedid_resource" "sp_a" {
 
 resource "verifiedid_resource" "application_b" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App b"
   }
@@ -265,6 +276,7 @@ resource "verifiedid_resource" "application_b" {
 
 resource "verifiedid_resource" "sp_b" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_b.output.appId
   }
@@ -272,6 +284,7 @@ resource "verifiedid_resource" "sp_b" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Collection Group"
     mailEnabled     = false
@@ -282,6 +295,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_collection" "test" {
   url           = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   api_version   = "beta"
   reference_ids = [verifiedid_resource.sp_a.id]
 }
@@ -292,6 +306,7 @@ func (r VerifiedIDTestResourceCollection) updateTwoMembers() string {
 	return `
 resource "verifiedid_resource" "application_a" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App a"
   }
@@ -302,6 +317,7 @@ resource "verifiedid_resource" "application_a" {
 
 resource "verifiedid_resource" "sp_a" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_a.output.appId
   }
@@ -309,6 +325,7 @@ resource "verifiedid_resource" "sp_a" {
 
 resource "verifiedid_resource" "application_b" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App b"
   }
@@ -319,6 +336,7 @@ resource "verifiedid_resource" "application_b" {
 
 resource "verifiedid_resource" "sp_b" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_b.output.appId
   }
@@ -326,6 +344,7 @@ resource "verifiedid_resource" "sp_b" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Collection Group"
     mailEnabled     = false
@@ -336,6 +355,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_collection" "test" {
   url         = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   api_version = "beta"
   reference_ids = [
     verifiedid_resource.sp_a.id,
@@ -349,6 +369,7 @@ func (r VerifiedIDTestResourceCollection) withRetry() string {
 	return `
 resource "verifiedid_resource" "application_a" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App a"
   }
@@ -359,6 +380,7 @@ resource "verifiedid_resource" "application_a" {
 
 resource "verifiedid_resource" "sp_a" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_a.output.appId
   }
@@ -366,6 +388,7 @@ resource "verifiedid_resource" "sp_a" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Collection Group"
     mailEnabled     = false
@@ -376,6 +399,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_collection" "test" {
   url           = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   api_version   = "beta"
   reference_ids = [verifiedid_resource.sp_a.id]
   retry = {
@@ -392,6 +416,7 @@ func (r VerifiedIDTestResourceCollection) withCreateTimeout() string {
 	return `
 resource "verifiedid_resource" "application_a" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App a"
   }
@@ -402,6 +427,7 @@ resource "verifiedid_resource" "application_a" {
 
 resource "verifiedid_resource" "sp_a" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_a.output.appId
   }
@@ -409,6 +435,7 @@ resource "verifiedid_resource" "sp_a" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Collection Group"
     mailEnabled     = false
@@ -419,6 +446,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_collection" "test" {
   url = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   timeouts {
     create = "1ns"
   }
@@ -432,6 +460,7 @@ func (r VerifiedIDTestResourceCollection) withUpdateTimeoutTwoMembers() string {
 	return `
 resource "verifiedid_resource" "application_a" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App a"
   }
@@ -442,6 +471,7 @@ resource "verifiedid_resource" "application_a" {
 
 resource "verifiedid_resource" "sp_a" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_a.output.appId
   }
@@ -449,6 +479,7 @@ resource "verifiedid_resource" "sp_a" {
 
 resource "verifiedid_resource" "application_b" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App b"
   }
@@ -459,6 +490,7 @@ resource "verifiedid_resource" "application_b" {
 
 resource "verifiedid_resource" "sp_b" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_b.output.appId
   }
@@ -466,6 +498,7 @@ resource "verifiedid_resource" "sp_b" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Collection Group"
     mailEnabled     = false
@@ -476,6 +509,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_collection" "test" {
   url = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   timeouts {
     update = "1ns"
   }
@@ -492,6 +526,7 @@ func (r VerifiedIDTestResourceCollection) withReadTimeoutOneMember() string {
 	return `
 resource "verifiedid_resource" "application_a" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Collection App a"
   }
@@ -502,6 +537,7 @@ resource "verifiedid_resource" "application_a" {
 
 resource "verifiedid_resource" "sp_a" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application_a.output.appId
   }
@@ -509,6 +545,7 @@ resource "verifiedid_resource" "sp_a" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "Collection Group"
     mailEnabled     = false
@@ -519,6 +556,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource_collection" "test" {
   url = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   timeouts {
     read = "1ns"
   }
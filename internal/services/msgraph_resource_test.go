@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"testing"
@@ -153,6 +154,31 @@ func TestAcc_ResourceRetry(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceFakeServerReadRetry(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_resource", "test")
+
+	r := VerifiedIDTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.fakeServerWithReadRetry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+		{
+			// The refresh fails with a status code only the read retry of the resource retries.
+			PreConfig: func() {
+				data.FakeServer.InjectFault(acceptance.Fault{Method: http.MethodGet, Path: "authorities", StatusCode: http.StatusConflict, Count: 1})
+			},
+			Config: r.fakeServerWithReadRetry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+	})
+}
+
 func TestAcc_ResourceTimeouts_Create(t *testing.T) {
 	data := acceptance.BuildTestData(t, "verifiedid_resource", "test")
 
@@ -199,7 +225,7 @@ func (r VerifiedIDTestResource) Exists(ctx context.Context, client *clients.Clie
 		checkUrl = url
 	}
 
-	_, err := client.VerifiedIDClient.Read(ctx, checkUrl, apiVersion, clients.DefaultRequestOptions())
+	_, err := client.API(state.Attributes["target_api"]).Read(ctx, checkUrl, apiVersion, clients.DefaultRequestOptions())
 	if err == nil {
 		b := true
 		return &b, nil
@@ -214,16 +240,18 @@ func (r VerifiedIDTestResource) Exists(ctx context.Context, client *clients.Clie
 func (r VerifiedIDTestResource) ImportIdFunc(tfState *terraform.State) (string, error) {
 	state := tfState.RootModule().Resources["verifiedid_resource.test"].Primary
 	url := state.Attributes["url"]
+	query := fmt.Sprintf("?target-api=%s", state.Attributes["target_api"])
 	if !strings.Contains(url, "/$ref") {
-		return fmt.Sprintf("%s/%s", url, state.ID), nil
+		return fmt.Sprintf("%s/%s%s", url, state.ID, query), nil
 	}
-	return strings.ReplaceAll(url, "/$ref", fmt.Sprintf("/%s/$ref", state.ID)), nil
+	return strings.ReplaceAll(url, "/$ref", fmt.Sprintf("/%s/$ref", state.ID)) + query, nil
 }
 
 func (r VerifiedIDTestResource) basic(data acceptance.TestData) string {
 	return `
 resource "verifiedid_resource" "test" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Demo App"
   }
@@ -235,6 +263,7 @@ func (r VerifiedIDTestResource) basicUpdate(data acceptance.TestData) string {
 	return `
 resource "verifiedid_resource" "test" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Demo App Updated"
   }
@@ -246,6 +275,7 @@ func (r VerifiedIDTestResource) groupMember() string {
 	return `
 resource "verifiedid_resource" "application" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "My Application"
   }
@@ -256,6 +286,7 @@ resource "verifiedid_resource" "application" {
 
 resource "verifiedid_resource" "servicePrincipal_application" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application.output.appId
   }
@@ -263,6 +294,7 @@ resource "verifiedid_resource" "servicePrincipal_application" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "My Group"
     mailEnabled     = false
@@ -273,6 +305,7 @@ resource "verifiedid_resource" "group" {
 
 resource "verifiedid_resource" "test" {
   url = "groups/${verifiedid_resource.group.id}/members/$ref"
+  target_api = "graph"
   body = {
     "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${verifiedid_resource.servicePrincipal_application.id}"
   }
@@ -284,6 +317,7 @@ func (r VerifiedIDTestResource) groupOwnerBind(displayName string) string {
 	return fmt.Sprintf(`
 resource "verifiedid_resource" "application" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "My Application"
   }
@@ -294,6 +328,7 @@ resource "verifiedid_resource" "application" {
 
 resource "verifiedid_resource" "servicePrincipal_application" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application.output.appId
   }
@@ -301,6 +336,7 @@ resource "verifiedid_resource" "servicePrincipal_application" {
 
 resource "verifiedid_resource" "test" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "%s"
     mailEnabled     = false
@@ -318,6 +354,7 @@ func (r VerifiedIDTestResource) withRetry() string {
 	return `
 resource "verifiedid_resource" "test" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Demo App Retry"
   }
@@ -330,10 +367,27 @@ resource "verifiedid_resource" "test" {
 }`
 }
 
+func (r VerifiedIDTestResource) fakeServerWithReadRetry(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "verifiedid_resource" "test" {
+  url = "verifiableCredentials/authorities"
+  body = {
+    name      = "Demo Authority %s"
+    didMethod = "ion"
+  }
+  retry = {
+    status_codes = [409]
+    operations   = ["read"]
+  }
+}
+`, data.RandomString)
+}
+
 func (r VerifiedIDTestResource) withCreateTimeout() string {
 	return `
 resource "verifiedid_resource" "test" {
   url = "applications"
+  target_api = "graph"
   timeouts {
     create = "1ns"
   }
@@ -348,6 +402,7 @@ func (r VerifiedIDTestResource) withUpdateTimeout() string {
 	return `
 resource "verifiedid_resource" "test" {
   url = "applications"
+  target_api = "graph"
   timeouts {
     update = "1ns"
   }
@@ -39,7 +39,7 @@ func NewVerifiedIDUpdateResource() resource.Resource {
 
 // VerifiedIDUpdateResource defines the resource implementation.
 type VerifiedIDUpdateResource struct {
-	client *clients.VerifiedIDClient
+	client *clients.Client
 }
 
 func (r *VerifiedIDUpdateResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
@@ -50,6 +50,7 @@ func (r *VerifiedIDUpdateResource) ConfigValidators(ctx context.Context) []resou
 type VerifiedIDUpdateResourceModel struct {
 	Id                    types.String      `tfsdk:"id"`
 	ApiVersion            types.String      `tfsdk:"api_version"`
+	TargetAPI             types.String      `tfsdk:"target_api"`
 	Url                   types.String      `tfsdk:"url"`
 	Body                  types.Dynamic     `tfsdk:"body"`
 	IgnoreMissingProperty types.Bool        `tfsdk:"ignore_missing_property"`
@@ -95,6 +96,19 @@ func (r *VerifiedIDUpdateResource) Schema(ctx context.Context, req resource.Sche
 				Default: stringdefault.StaticString("v1.0"),
 			},
 
+			"target_api": schema.StringAttribute{
+				MarkdownDescription: docstrings.TargetAPI(),
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clients.TargetAPIs...),
+				},
+				Default: stringdefault.StaticString(clients.TargetAPIVerifiedID),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
 			"body": schema.DynamicAttribute{
 				MarkdownDescription: docstrings.Body(),
 				Optional:            true,
@@ -150,7 +164,7 @@ func (r *VerifiedIDUpdateResource) Schema(ctx context.Context, req resource.Sche
 
 func (r *VerifiedIDUpdateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if v, ok := req.ProviderData.(*clients.Client); ok {
-		r.client = v.VerifiedIDClient
+		r.client = v
 	}
 }
 
@@ -177,8 +191,10 @@ func (r *VerifiedIDUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.
 
 	var writeTimeout time.Duration
 	var diags diag.Diagnostics
+	operation := retry.OperationUpdate
 	if isCreate {
 		writeTimeout, diags = model.Timeouts.Create(ctx, 30*time.Minute)
+		operation = retry.OperationCreate
 	} else {
 		writeTimeout, diags = model.Timeouts.Update(ctx, 30*time.Minute)
 	}
@@ -197,23 +213,24 @@ func (r *VerifiedIDUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.
 		return
 	}
 
+	client := r.client.API(model.TargetAPI.ValueString())
 	options := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.UpdateQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, operation),
 	}
-	_, err = r.client.Update(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
+	_, err = client.Update(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
 	if err != nil {
-		diagnostics.AddError("Failed to create resource", err.Error())
+		addBodyResponseErrorDiagnostic(diagnostics, "Failed to create resource", err)
 		return
 	}
 
 	options = clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, operation),
 	}
-	responseBody, err := r.client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
+	responseBody, err := client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
 	if err != nil {
-		diagnostics.AddError("Failed to read data source", err.Error())
+		addResponseErrorDiagnostic(diagnostics, "Failed to read data source", err)
 		return
 	}
 	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
@@ -245,18 +262,19 @@ func (r *VerifiedIDUpdateResource) Read(ctx context.Context, req resource.ReadRe
 		model.ApiVersion = types.StringValue("v1.0")
 	}
 
+	client := r.client.API(model.TargetAPI.ValueString())
 	options := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    client.RetryOptions(model.Retry, retry.OperationRead),
 	}
-	responseBody, err := r.client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
+	responseBody, err := client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("Error reading %q - removing from state", model.Id.ValueString()))
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to read data source", err.Error())
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read data source", err)
 		return
 	}
 
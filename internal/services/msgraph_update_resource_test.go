@@ -131,7 +131,7 @@ func (r VerifiedIDTestUpdateResource) Exists(ctx context.Context, client *client
 	apiVersion := state.Attributes["api_version"]
 	url := state.Attributes["url"]
 
-	_, err := client.VerifiedIDClient.Read(ctx, url, apiVersion, clients.DefaultRequestOptions())
+	_, err := client.API(state.Attributes["target_api"]).Read(ctx, url, apiVersion, clients.DefaultRequestOptions())
 	if err == nil {
 		b := true
 		return &b, nil
@@ -147,6 +147,7 @@ func (r VerifiedIDTestUpdateResource) basic(displayName string) string {
 	return fmt.Sprintf(`
 resource "verifiedid_resource" "application" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Demo App"
   }
@@ -158,6 +159,7 @@ resource "verifiedid_resource" "application" {
 
 resource "verifiedid_update_resource" "test" {
   url = "applications/${verifiedid_resource.application.id}"
+  target_api = "graph"
   body = {
     displayName = "%s"
   }
@@ -171,6 +173,7 @@ func (r VerifiedIDTestUpdateResource) withUpdateTimeout(displayName string) stri
 
 resource "verifiedid_update_resource" "test" {
   url = "applications/${verifiedid_resource.application.id}"
+  target_api = "graph"
   body = {
     displayName = "%s"
   }
@@ -187,6 +190,7 @@ func (r VerifiedIDTestUpdateResource) withReadTimeout(displayName string) string
 
 resource "verifiedid_update_resource" "test" {
   url = "applications/${verifiedid_resource.application.id}"
+  target_api = "graph"
   body = {
     displayName = "%s"
   }
@@ -203,6 +207,7 @@ func (r VerifiedIDTestUpdateResource) withCreateTimeout(displayName string) stri
 
 resource "verifiedid_update_resource" "test" {
   url = "applications/${verifiedid_resource.application.id}"
+  target_api = "graph"
   body = {
     displayName = "%s"
   }
@@ -219,6 +224,7 @@ func (r VerifiedIDTestUpdateResource) applicationOnly() string {
 	return `
 resource "verifiedid_resource" "application" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Demo App"
   }
@@ -234,6 +240,7 @@ func (r VerifiedIDTestUpdateResource) withRetry() string {
 	return `
 resource "verifiedid_resource" "application" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "Demo App"
   }
@@ -245,6 +252,7 @@ resource "verifiedid_resource" "application" {
 
 resource "verifiedid_update_resource" "test" {
   url = "applications/${verifiedid_resource.application.id}"
+  target_api = "graph"
   body = {
     displayName = "Demo App Updated With Retry"
   }
@@ -262,6 +270,7 @@ func (r VerifiedIDTestUpdateResource) groupWithOwnerBase() string {
 	return `
 resource "verifiedid_resource" "application" {
   url = "applications"
+  target_api = "graph"
   body = {
     displayName = "My Application"
   }
@@ -272,6 +281,7 @@ resource "verifiedid_resource" "application" {
 
 resource "verifiedid_resource" "servicePrincipal_application" {
   url = "servicePrincipals"
+  target_api = "graph"
   body = {
     appId = verifiedid_resource.application.output.appId
   }
@@ -279,6 +289,7 @@ resource "verifiedid_resource" "servicePrincipal_application" {
 
 resource "verifiedid_resource" "group" {
   url = "groups"
+  target_api = "graph"
   body = {
     displayName     = "My Group Owners Bind"
     mailEnabled     = false
@@ -301,6 +312,7 @@ func (r VerifiedIDTestUpdateResource) groupWithOwnerUpdate(displayName string) s
 
 resource "verifiedid_update_resource" "test" {
   url = "groups/${verifiedid_resource.group.id}"
+  target_api = "graph"
   body = {
     displayName = "%s"
   }
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+const (
+	hintTenantNotOnboarded = "The tenant isn't onboarded to Verified ID. Onboard it with the `verifiedid_tenant_onboarding` resource or in the Microsoft Entra admin center, then retry."
+	hintUnauthorized       = "The access token was rejected. Check the credential of the provider, and that `token_scope` matches the API being called."
+	hintMissingRole        = "The identity used by the provider isn't allowed to perform the operation. An application needs a permission of the Verifiable Credentials Service Admin API granted with admin consent, a user needs the Authentication Policy Administrator role."
+	hintConflict           = "A resource with the same name already exists, for example a contract in the same authority, or it was changed concurrently. Choose another name, or import the existing resource with `terraform import`."
+	hintThrottled          = "The requests were throttled by the service. Retry later, or lower the number of parallel operations with `terraform apply -parallelism`."
+	hintChangedOutside     = "The resource was changed outside Terraform since it was last read, for example by another pipeline. Refresh the state with `terraform apply -refresh-only` and re-plan, or set `disable_etag` if the endpoint doesn't support ETags."
+)
+
+// responseErrorCodeHints are the remediation hints of well-known error codes, the keys are lower case.
+var responseErrorCodeHints = map[string]string{
+	"tenantnotonboarded":               hintTenantNotOnboarded,
+	"forbidden":                        hintMissingRole,
+	"accessdenied":                     hintMissingRole,
+	"authorization_requestdenied":      hintMissingRole,
+	"insufficientpermissions":          hintMissingRole,
+	"contractnamealreadyexists":        hintConflict,
+	"duplicatecontractname":            hintConflict,
+	"invalidauthenticationtoken":       hintUnauthorized,
+	"invalidauthenticationtokentenant": hintUnauthorized,
+}
+
+// responseErrorStatusHints are the remediation hints used when the error code has no hint of its own.
+var responseErrorStatusHints = map[int]string{
+	http.StatusUnauthorized:    hintUnauthorized,
+	http.StatusForbidden:       hintMissingRole,
+	http.StatusConflict:        hintConflict,
+	http.StatusTooManyRequests: hintThrottled,
+}
+
+// addResponseErrorDiagnostic adds an error diagnostic for a failed request. Errors returned by the service are
+// decoded into a concise summary and a detail with the error code, the request IDs and a remediation hint, other
+// errors are added as they are.
+func addResponseErrorDiagnostic(diags *diag.Diagnostics, summary string, err error) {
+	details, ok := utils.ParseResponseError(err)
+	if !ok {
+		diags.AddError(summary, err.Error())
+		return
+	}
+	diags.AddError(responseErrorSummary(summary, details), responseErrorDetail(details))
+}
+
+// addResponseErrorDiagnosticWithContext is addResponseErrorDiagnostic with a first paragraph, for example the state the
+// operation was left in or how to fix the failure, prepended to the detail.
+func addResponseErrorDiagnosticWithContext(diags *diag.Diagnostics, summary string, context string, err error) {
+	details, ok := utils.ParseResponseError(err)
+	if !ok {
+		diags.AddError(summary, fmt.Sprintf("%s\n\n%s", context, err.Error()))
+		return
+	}
+	diags.AddError(responseErrorSummary(summary, details), fmt.Sprintf("%s\n\n%s", context, responseErrorDetail(details)))
+}
+
+// addBodyResponseErrorDiagnostic is addResponseErrorDiagnostic for the resources with a `body` attribute, the error is
+// attributed to the path of the `body` the service reports as its target.
+func addBodyResponseErrorDiagnostic(diags *diag.Diagnostics, summary string, err error) {
+	details, ok := utils.ParseResponseError(err)
+	if !ok {
+		diags.AddError(summary, err.Error())
+		return
+	}
+	if details.Target == "" {
+		diags.AddError(responseErrorSummary(summary, details), responseErrorDetail(details))
+		return
+	}
+	diags.AddAttributeError(bodyTargetPath(details.Target), responseErrorSummary(summary, details), responseErrorDetail(details))
+}
+
+func responseErrorSummary(summary string, details *utils.ResponseErrorDetails) string {
+	if details.StatusCode == http.StatusPreconditionFailed {
+		return fmt.Sprintf("%s: resource changed outside Terraform", summary)
+	}
+	if codes := details.ErrorCodes(); len(codes) != 0 {
+		return fmt.Sprintf("%s: %s", summary, codes[0])
+	}
+	return fmt.Sprintf("%s: %d %s", summary, details.StatusCode, http.StatusText(details.StatusCode))
+}
+
+func responseErrorDetail(details *utils.ResponseErrorDetails) string {
+	lines := make([]string, 0)
+	if details.Message != "" {
+		lines = append(lines, details.Message)
+	}
+	if details.InnerMessage != "" && details.InnerMessage != details.Message {
+		lines = append(lines, details.InnerMessage)
+	}
+	if len(lines) != 0 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, fmt.Sprintf("Status: %d %s", details.StatusCode, http.StatusText(details.StatusCode)))
+	if details.Code != "" {
+		lines = append(lines, fmt.Sprintf("Error code: %s", details.Code))
+	}
+	if details.InnerCode != "" {
+		lines = append(lines, fmt.Sprintf("Inner error code: %s", details.InnerCode))
+	}
+	if details.Target != "" {
+		lines = append(lines, fmt.Sprintf("Target: %s", details.Target))
+	}
+	if details.RequestId != "" {
+		lines = append(lines, fmt.Sprintf("Request ID: %s", details.RequestId))
+	}
+	if details.CorrelationId != "" {
+		lines = append(lines, fmt.Sprintf("Correlation ID: %s", details.CorrelationId))
+	}
+
+	if hint := responseErrorHint(details); hint != "" {
+		lines = append(lines, "", hint)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func responseErrorHint(details *utils.ResponseErrorDetails) string {
+	if details.StatusCode == http.StatusPreconditionFailed {
+		return hintChangedOutside
+	}
+	for _, code := range details.ErrorCodes() {
+		if hint, ok := responseErrorCodeHints[strings.ToLower(code)]; ok {
+			return hint
+		}
+	}
+	return responseErrorStatusHints[details.StatusCode]
+}
+
+var bodyTargetIndexRegex = regexp.MustCompile(`\[(\d+)\]`)
+
+// bodyTargetPath converts the target of an error, e.g. `displays[0].card.title`, into the path of the `body` attribute.
+func bodyTargetPath(target string) path.Path {
+	target = strings.TrimPrefix(strings.TrimPrefix(target, "$"), ".")
+	target = strings.TrimPrefix(target, "body.")
+	target = bodyTargetIndexRegex.ReplaceAllString(target, ".$1")
+
+	p := path.Root("body")
+	for _, step := range strings.Split(target, ".") {
+		if step == "" {
+			continue
+		}
+		if index, err := strconv.ParseInt(step, 10, 64); err == nil {
+			p = p.AtListIndex(int(index))
+		} else {
+			p = p.AtName(step)
+		}
+	}
+	return p
+}
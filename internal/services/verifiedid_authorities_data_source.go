@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VerifiedIDAuthoritiesDataSource{}
+
+func NewVerifiedIDAuthoritiesDataSource() datasource.DataSource {
+	return &VerifiedIDAuthoritiesDataSource{}
+}
+
+// VerifiedIDAuthoritiesDataSource defines the data source implementation.
+type VerifiedIDAuthoritiesDataSource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDAuthoritiesDataSourceModel describes the data source data model.
+type VerifiedIDAuthoritiesDataSourceModel struct {
+	Id                types.String                     `tfsdk:"id"`
+	Authorities       []authoritySummaryModel          `tfsdk:"authorities"`
+	AuthoritiesByName map[string]authoritySummaryModel `tfsdk:"authorities_by_name"`
+	Retry             retry.Value                      `tfsdk:"retry"`
+	Timeouts          timeouts.Value                   `tfsdk:"timeouts"`
+}
+
+type authoritySummaryModel struct {
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Did              types.String `tfsdk:"did"`
+	Status           types.String `tfsdk:"status"`
+	LinkedDomainUrls []string     `tfsdk:"linked_domain_urls"`
+}
+
+func (r *VerifiedIDAuthoritiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authorities"
+}
+
+func (r *VerifiedIDAuthoritiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	authorityAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the authority.",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The friendly name of the authority.",
+			Computed:            true,
+		},
+		"did": schema.StringAttribute{
+			MarkdownDescription: "The decentralized identifier (DID) of the authority.",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "The status of the authority, for example `Enabled`.",
+			Computed:            true,
+		},
+		"linked_domain_urls": schema.ListAttribute{
+			MarkdownDescription: "The domains linked to the authority DID.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the Microsoft Entra Verified ID authorities of the tenant.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the authorities collection.",
+				Computed:            true,
+			},
+
+			"authorities": schema.ListNestedAttribute{
+				MarkdownDescription: "The authorities of the tenant.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: authorityAttributes,
+				},
+			},
+
+			"authorities_by_name": schema.MapNestedAttribute{
+				MarkdownDescription: "The authorities of the tenant keyed by name, with one entry per name. Authority names aren't unique: if several authorities share a name, only the last one returned by the API is kept and a warning is raised, `authorities` lists all of them.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: authorityAttributes,
+				},
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDAuthoritiesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDAuthoritiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model VerifiedIDAuthoritiesDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	responseBody, err := r.client.List(ctx, authoritiesUrl(), verifiedIDApiVersion, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to list authorities", err)
+		return
+	}
+
+	var authorities authorityListApiModel
+	if err := decodeResponseBody(responseBody, &authorities); err != nil {
+		resp.Diagnostics.AddError("Failed to decode authorities", err.Error())
+		return
+	}
+
+	model.Id = types.StringValue(authoritiesUrl())
+	model.Authorities = make([]authoritySummaryModel, 0, len(authorities.Value))
+	model.AuthoritiesByName = make(map[string]authoritySummaryModel, len(authorities.Value))
+	for _, v := range authorities.Value {
+		authority := authoritySummaryModel{
+			Id:               types.StringValue(v.Id),
+			Name:             types.StringValue(v.Name),
+			Status:           types.StringValue(v.Status),
+			Did:              types.StringValue(""),
+			LinkedDomainUrls: []string{},
+		}
+		if v.DidModel != nil {
+			authority.Did = types.StringValue(v.DidModel.Did)
+			authority.LinkedDomainUrls = stringsOrEmpty(v.DidModel.LinkedDomainUrls)
+		}
+		model.Authorities = append(model.Authorities, authority)
+		if _, ok := model.AuthoritiesByName[v.Name]; ok {
+			resp.Diagnostics.AddWarning(
+				"Duplicated authority name",
+				fmt.Sprintf("Several authorities are named %q, `authorities_by_name` only keeps the last one returned by the API. Use `authorities` to choose between them.", v.Name),
+			)
+		}
+		model.AuthoritiesByName[v.Name] = authority
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,74 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+)
+
+type VerifiedIDAuthoritiesTestDataSource struct{}
+
+func TestAcc_AuthoritiesDataSourceBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.verifiedid_authorities", "test")
+	r := VerifiedIDAuthoritiesTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").HasValue("verifiableCredentials/authorities"),
+				check.That(data.ResourceName).Key("authorities.#").Exists(),
+				check.That(data.ResourceName).Key(fmt.Sprintf("authorities_by_name.Demo Authority %s.id", data.RandomString)).IsUUID(),
+			),
+		},
+	})
+}
+
+func TestAcc_AuthoritiesDataSourceFakeServerDuplicatedName(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "data.verifiedid_authorities", "test")
+	r := VerifiedIDAuthoritiesTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.duplicatedName(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("authorities.#").HasValue("2"),
+				check.That(data.ResourceName).Key("authorities_by_name.%").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (r VerifiedIDAuthoritiesTestDataSource) duplicatedName(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "first" {
+  name               = "Demo Authority %s"
+  linked_domain_urls = ["https://%s.contoso.com/"]
+}
+
+resource "verifiedid_authority" "second" {
+  name               = "Demo Authority %s"
+  linked_domain_urls = ["https://%s.fabrikam.com/"]
+}
+
+data "verifiedid_authorities" "test" {
+  depends_on = [verifiedid_authority.first, verifiedid_authority.second]
+}
+`, data.RandomString, data.RandomString, data.RandomString, data.RandomString)
+}
+
+func (r VerifiedIDAuthoritiesTestDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "test" {
+  name               = "Demo Authority %s"
+  linked_domain_urls = ["https://%s.contoso.com/"]
+}
+
+data "verifiedid_authorities" "test" {
+  depends_on = [verifiedid_authority.test]
+}
+`, data.RandomString, data.RandomString)
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VerifiedIDAuthorityKeyRotationResource{}
+
+// synchronizeWithDidDocumentRetryInterval is the delay between two attempts to synchronize the DID after the rotation.
+// The transient errors are retried until the create timeout expires because the rotation can't be run again.
+const synchronizeWithDidDocumentRetryInterval = 10 * time.Second
+
+func NewVerifiedIDAuthorityKeyRotationResource() resource.Resource {
+	return &VerifiedIDAuthorityKeyRotationResource{}
+}
+
+// VerifiedIDAuthorityKeyRotationResource defines the resource implementation.
+type VerifiedIDAuthorityKeyRotationResource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDAuthorityKeyRotationResourceModel describes the resource data model.
+type VerifiedIDAuthorityKeyRotationResourceModel struct {
+	Id                    types.String   `tfsdk:"id"`
+	AuthorityId           types.String   `tfsdk:"authority_id"`
+	DomainUrl             types.String   `tfsdk:"domain_url"`
+	RotationTrigger       types.String   `tfsdk:"rotation_trigger"`
+	SigningKeys           types.List     `tfsdk:"signing_keys"`
+	NewSigningKeys        types.List     `tfsdk:"new_signing_keys"`
+	DidDocumentJson       types.String   `tfsdk:"did_document_json"`
+	VerificationMethodIds types.List     `tfsdk:"verification_method_ids"`
+	Retry                 retry.Value    `tfsdk:"retry"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VerifiedIDAuthorityKeyRotationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authority_key_rotation"
+}
+
+func (r *VerifiedIDAuthorityKeyRotationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Rotates the signing key of an authority and synchronizes the DID of the authority with the new key. " +
+			"The rotation runs when the resource is created and every time `rotation_trigger` changes. " +
+			"The regenerated DID document is exported in `did_document_json`, it must be published to `{domain_url}/.well-known/did.json` for the new key to be used.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the action used to rotate the signing key.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"authority_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"domain_url": schema.StringAttribute{
+				MarkdownDescription: "The linked domain the DID document is regenerated for, for example `https://www.contoso.com/`. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsHttpsURL(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary value, for example a date, which causes the signing key to be rotated again when it changes.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"signing_keys": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the signing keys of the authority after the rotation.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+
+			"new_signing_keys": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the signing keys added by the rotation.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+
+			"did_document_json": schema.StringAttribute{
+				MarkdownDescription: "The DID document regenerated after the rotation, as a JSON string.",
+				Computed:            true,
+			},
+
+			"verification_method_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the verification methods (public keys) of the regenerated DID document.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDAuthorityKeyRotationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDAuthorityKeyRotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *VerifiedIDAuthorityKeyRotationResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	authorityId := model.AuthorityId.ValueString()
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationCreate),
+	}
+
+	before, err := readAuthorityDidModel(ctx, r.client, authorityId, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read authority", err)
+		return
+	}
+
+	// The new key must be added to the DID document before it is used, so the order of the two actions matters.
+	if _, err := r.client.Action(ctx, http.MethodPost, rotateSigningKeyUrl(authorityId), verifiedIDApiVersion, nil, options); err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to rotate signing key", err)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Rotated the signing key of authority %q", authorityId))
+
+	for {
+		_, err := r.client.Action(ctx, http.MethodPost, synchronizeWithDidDocumentUrl(authorityId), verifiedIDApiVersion, nil, options)
+		if err == nil {
+			break
+		}
+
+		notSynchronized := fmt.Sprintf("The signing key of authority %q was rotated, but the DID could not be synchronized. Call `synchronizeWithDidDocument` to complete the rotation, for example with a `verifiedid_resource_action`, applying again would rotate the key again.", authorityId)
+		if !utils.ResponseErrorWasTransient(err) {
+			addResponseErrorDiagnosticWithContext(&resp.Diagnostics, "Failed to synchronize with DID document", notSynchronized, err)
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Failed to synchronize authority %q with its DID document, retrying: %v", authorityId, err))
+		select {
+		case <-ctx.Done():
+			addResponseErrorDiagnosticWithContext(&resp.Diagnostics, "Failed to synchronize with DID document", notSynchronized, err)
+			return
+		case <-time.After(synchronizeWithDidDocumentRetryInterval):
+		}
+	}
+
+	// The rotation is complete, so the resource is saved even if its outputs can't be read: an error would taint it and
+	// the next apply would rotate the key again. The outputs left null are read by the next refresh.
+	model.Id = types.StringValue(rotateSigningKeyUrl(authorityId))
+	model.SigningKeys = types.ListNull(types.StringType)
+	model.NewSigningKeys = types.ListNull(types.StringType)
+	model.DidDocumentJson = types.StringNull()
+	model.VerificationMethodIds = types.ListNull(types.StringType)
+	if resp.Diagnostics.Append(setSigningKeysBeforeRotation(ctx, resp.Private, before.SigningKeys)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if summary, err := r.readOutputs(ctx, model, before.SigningKeys, options); err != nil {
+		resp.Diagnostics.AddWarning(summary, fmt.Sprintf("The signing key of authority %q was rotated and synchronized, but the outputs of the rotation could not be read. They are read again by the next refresh.\n\n%s", authorityId, err.Error()))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// readOutputs sets the signing keys of the authority and its DID document after the rotation. It returns the summary
+// of the diagnostic with the error of the request which failed.
+func (r *VerifiedIDAuthorityKeyRotationResource) readOutputs(ctx context.Context, model *VerifiedIDAuthorityKeyRotationResourceModel, signingKeysBefore []string, options clients.RequestOptions) (string, error) {
+	authorityId := model.AuthorityId.ValueString()
+	after, err := readAuthorityDidModel(ctx, r.client, authorityId, options)
+	if err != nil {
+		return "Failed to read authority", err
+	}
+
+	previousKeys := make(map[string]bool)
+	for _, v := range signingKeysBefore {
+		previousKeys[v] = true
+	}
+	newKeys := make([]string, 0)
+	for _, v := range after.SigningKeys {
+		if !previousKeys[v] {
+			newKeys = append(newKeys, v)
+		}
+	}
+
+	model.SigningKeys = stringListValue(after.SigningKeys)
+	model.NewSigningKeys = stringListValue(newKeys)
+
+	didDocumentJson, didDocument, err := generateDidDocument(ctx, r.client, authorityId, model.DomainUrl.ValueString(), options)
+	if err != nil {
+		return "Failed to generate DID document", err
+	}
+	model.DidDocumentJson = types.StringValue(didDocumentJson)
+	model.VerificationMethodIds = stringListValue(didDocument.verificationMethodIds())
+	return "", nil
+}
+
+func (r *VerifiedIDAuthorityKeyRotationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model *VerifiedIDAuthorityKeyRotationResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// The outputs describe the result of the rotation, they are only refreshed when the rotation runs again.
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	if _, err := r.client.Read(ctx, authorityUrl(model.AuthorityId.ValueString()), verifiedIDApiVersion, options); err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Authority %q was not found - removing from state", model.AuthorityId.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read authority", err)
+		return
+	}
+
+	// The outputs of a rotation whose outputs couldn't be read when it was created are read now.
+	if model.DidDocumentJson.IsNull() {
+		signingKeysBefore, diags := getSigningKeysBeforeRotation(ctx, req.Private)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+		if summary, err := r.readOutputs(ctx, model, signingKeysBefore, options); err != nil {
+			addResponseErrorDiagnostic(&resp.Diagnostics, summary, err)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDAuthorityKeyRotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model, state *VerifiedIDAuthorityKeyRotationResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the retry and timeouts settings can be changed in place, the rotation is not run again.
+	model.SigningKeys = state.SigningKeys
+	model.NewSigningKeys = state.NewSigningKeys
+	model.DidDocumentJson = state.DidDocumentJson
+	model.VerificationMethodIds = state.VerificationMethodIds
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// signingKeysBeforeRotationPrivateStateKey is the key of the private state storing the signing keys of the authority
+// before the rotation, which are needed to find the new keys until the outputs of the rotation are read.
+const signingKeysBeforeRotationPrivateStateKey = "signing_keys_before_rotation"
+
+func getSigningKeysBeforeRotation(ctx context.Context, private privateStateGetter) ([]string, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, signingKeysBeforeRotationPrivateStateKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+	var signingKeys []string
+	if err := json.Unmarshal(data, &signingKeys); err != nil {
+		diags.AddError("Invalid private state", fmt.Sprintf("The signing keys of the authority before the rotation are invalid: %s", err.Error()))
+	}
+	return signingKeys, diags
+}
+
+func setSigningKeysBeforeRotation(ctx context.Context, private privateStateSetter, signingKeys []string) diag.Diagnostics {
+	data, err := json.Marshal(stringsOrEmpty(signingKeys))
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, signingKeysBeforeRotationPrivateStateKey, data)
+}
+
+func (r *VerifiedIDAuthorityKeyRotationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A rotation can't be undone, the resource is only removed from the state.
+}
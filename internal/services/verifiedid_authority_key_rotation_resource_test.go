@@ -0,0 +1,171 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+)
+
+type VerifiedIDAuthorityKeyRotationTestResource struct{}
+
+func TestAcc_AuthorityKeyRotationBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_authority_key_rotation", "test")
+
+	r := VerifiedIDAuthorityKeyRotationTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "first"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("signing_keys.#").Exists(),
+				check.That(data.ResourceName).Key("new_signing_keys.#").HasValue("1"),
+				check.That(data.ResourceName).Key("did_document_json").IsJson(),
+			),
+		},
+		{
+			Config: r.basic(data, "second"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("new_signing_keys.#").HasValue("1"),
+				check.That(data.ResourceName).Key("did_document_json").IsJson(),
+			),
+		},
+	})
+}
+
+func TestAcc_AuthorityKeyRotationFakeServerSynchronizeRetried(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_authority_key_rotation", "test")
+
+	r := VerifiedIDAuthorityKeyRotationTestResource{}
+	var authorityId string
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.authority(data),
+			Check: func(s *terraform.State) error {
+				authorityId = s.RootModule().Resources["verifiedid_authority.test"].Primary.ID
+				return nil
+			},
+		},
+		{
+			PreConfig: func() {
+				data.FakeServer.InjectFault(acceptance.Fault{
+					Method:     http.MethodPost,
+					Path:       fmt.Sprintf("authorities/%s/didInfo/synchronizeWithDidDocument", authorityId),
+					StatusCode: http.StatusServiceUnavailable,
+					Count:      1,
+				})
+			},
+			Config: r.basic(data, "first"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("signing_keys.#").HasValue("2"),
+				check.That(data.ResourceName).Key("new_signing_keys.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAcc_AuthorityKeyRotationFakeServerSynchronizeFailsFast(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_authority_key_rotation", "test")
+
+	r := VerifiedIDAuthorityKeyRotationTestResource{}
+	var authorityId string
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.authority(data),
+			Check: func(s *terraform.State) error {
+				authorityId = s.RootModule().Resources["verifiedid_authority.test"].Primary.ID
+				return nil
+			},
+		},
+		{
+			// The second attempt would succeed, so the step only fails if the bad request isn't retried.
+			PreConfig: func() {
+				data.FakeServer.InjectFault(acceptance.Fault{
+					Method:     http.MethodPost,
+					Path:       fmt.Sprintf("authorities/%s/didInfo/synchronizeWithDidDocument", authorityId),
+					StatusCode: http.StatusBadRequest,
+					Count:      1,
+				})
+			},
+			Config:      r.basic(data, "first"),
+			ExpectError: regexp.MustCompile(`Failed to synchronize with DID document`),
+		},
+	})
+}
+
+func TestAcc_AuthorityKeyRotationFakeServerOutputsReadByRefresh(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_authority_key_rotation", "test")
+
+	r := VerifiedIDAuthorityKeyRotationTestResource{}
+	var authorityId string
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.authority(data),
+			Check: func(s *terraform.State) error {
+				authorityId = s.RootModule().Resources["verifiedid_authority.test"].Primary.ID
+				return nil
+			},
+		},
+		{
+			// The rotation is saved with a warning, so it isn't tainted and run again.
+			PreConfig: func() {
+				data.FakeServer.InjectFault(acceptance.Fault{
+					Method:     http.MethodPost,
+					Path:       fmt.Sprintf("authorities/%s/generateDidDocument", authorityId),
+					StatusCode: http.StatusBadRequest,
+					Count:      1,
+				})
+			},
+			Config: r.basic(data, "first"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("signing_keys.#").HasValue("2"),
+				check.That(data.ResourceName).Key("new_signing_keys.#").HasValue("1"),
+			),
+		},
+		{
+			Config: r.basic(data, "first"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("signing_keys.#").HasValue("2"),
+				check.That(data.ResourceName).Key("new_signing_keys.#").HasValue("1"),
+				check.That(data.ResourceName).Key("did_document_json").IsJson(),
+			),
+		},
+	})
+}
+
+func (r VerifiedIDAuthorityKeyRotationTestResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	// A rotation can't be undone, it exists as long as it is in the state.
+	b := true
+	return &b, nil
+}
+
+func (r VerifiedIDAuthorityKeyRotationTestResource) authority(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "test" {
+  name               = "Demo Authority %s"
+  linked_domain_urls = ["https://%s.contoso.com/"]
+}
+`, data.RandomString, data.RandomString)
+}
+
+func (r VerifiedIDAuthorityKeyRotationTestResource) basic(data acceptance.TestData, trigger string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "verifiedid_authority_key_rotation" "test" {
+  authority_id     = verifiedid_authority.test.id
+  domain_url       = "https://%s.contoso.com/"
+  rotation_trigger = "%s"
+}
+`, r.authority(data), data.RandomString, trigger)
+}
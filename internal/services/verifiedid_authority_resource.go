@@ -0,0 +1,570 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/docstrings"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &VerifiedIDAuthorityResource{}
+	_ resource.ResourceWithImportState    = &VerifiedIDAuthorityResource{}
+	_ resource.ResourceWithValidateConfig = &VerifiedIDAuthorityResource{}
+)
+
+func NewVerifiedIDAuthorityResource() resource.Resource {
+	return &VerifiedIDAuthorityResource{}
+}
+
+// VerifiedIDAuthorityResource defines the resource implementation.
+type VerifiedIDAuthorityResource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDAuthorityResourceModel describes the resource data model.
+type VerifiedIDAuthorityResourceModel struct {
+	Id               types.String   `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	LinkedDomainUrls types.List     `tfsdk:"linked_domain_urls"`
+	DidMethod        types.String   `tfsdk:"did_method"`
+	KeyStore         types.String   `tfsdk:"key_store"`
+	KeyVaultMetadata types.Object   `tfsdk:"key_vault_metadata"`
+	Did              types.String   `tfsdk:"did"`
+	DidModel         types.Object   `tfsdk:"did_model"`
+	Status           types.String   `tfsdk:"status"`
+	DisableETag      types.Bool     `tfsdk:"disable_etag"`
+	Retry            retry.Value    `tfsdk:"retry"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+type keyVaultMetadataModel struct {
+	SubscriptionId types.String `tfsdk:"subscription_id"`
+	ResourceGroup  types.String `tfsdk:"resource_group"`
+	ResourceName   types.String `tfsdk:"resource_name"`
+	ResourceUrl    types.String `tfsdk:"resource_url"`
+}
+
+var keyVaultMetadataAttributeTypes = map[string]attr.Type{
+	"subscription_id": types.StringType,
+	"resource_group":  types.StringType,
+	"resource_name":   types.StringType,
+	"resource_url":    types.StringType,
+}
+
+var didModelAttributeTypes = map[string]attr.Type{
+	"did":                 types.StringType,
+	"signing_keys":        types.ListType{ElemType: types.StringType},
+	"recovery_keys":       types.ListType{ElemType: types.StringType},
+	"update_keys":         types.ListType{ElemType: types.StringType},
+	"encryption_keys":     types.ListType{ElemType: types.StringType},
+	"linked_domain_urls":  types.ListType{ElemType: types.StringType},
+	"did_document_status": types.StringType,
+}
+
+func (r *VerifiedIDAuthorityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authority"
+}
+
+func (r *VerifiedIDAuthorityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Microsoft Entra Verified ID authority (issuer).",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The friendly name of the authority.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"linked_domain_urls": schema.ListAttribute{
+				MarkdownDescription: "The domains linked to the authority DID, for example `https://www.contoso.com/`.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(myvalidator.StringIsHttpsURL()),
+				},
+			},
+
+			"did_method": schema.StringAttribute{
+				MarkdownDescription: "The DID method used by the authority. Possible values are `web` and `ion`. Defaults to `web`. Changing this forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(didMethodWeb),
+				Validators: []validator.String{
+					stringvalidator.OneOf(didMethodWeb, didMethodIon),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"key_store": schema.StringAttribute{
+				MarkdownDescription: "Where the signing keys of the authority are stored. Possible values are `Managed` and `KeyVault`. Defaults to `Managed`. When set to `KeyVault`, `key_vault_metadata` must be specified. Changing this forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(keyStoreManaged),
+				Validators: []validator.String{
+					stringvalidator.OneOf(keyStoreManaged, keyStoreKeyVault),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"key_vault_metadata": schema.SingleNestedAttribute{
+				MarkdownDescription: "The Azure Key Vault which holds the signing keys of the authority. Only used when `key_store` is `KeyVault`. Changing this forces a new resource to be created.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"subscription_id": schema.StringAttribute{
+						MarkdownDescription: "The ID of the subscription the Key Vault belongs to.",
+						Required:            true,
+						Validators: []validator.String{
+							myvalidator.StringIsUUID(),
+						},
+					},
+					"resource_group": schema.StringAttribute{
+						MarkdownDescription: "The name of the resource group the Key Vault belongs to.",
+						Required:            true,
+					},
+					"resource_name": schema.StringAttribute{
+						MarkdownDescription: "The name of the Key Vault.",
+						Required:            true,
+					},
+					"resource_url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the Key Vault, for example `https://example.vault.azure.net/`.",
+						Required:            true,
+						Validators: []validator.String{
+							myvalidator.StringIsHttpsURL(),
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"did": schema.StringAttribute{
+				MarkdownDescription: "The decentralized identifier (DID) of the authority.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"did_model": schema.SingleNestedAttribute{
+				MarkdownDescription: "The DID model of the authority as reported by the service.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"did": schema.StringAttribute{
+						MarkdownDescription: "The decentralized identifier (DID) of the authority.",
+						Computed:            true,
+					},
+					"signing_keys": schema.ListAttribute{
+						MarkdownDescription: "The URLs of the signing keys.",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"recovery_keys": schema.ListAttribute{
+						MarkdownDescription: "The URLs of the recovery keys.",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"update_keys": schema.ListAttribute{
+						MarkdownDescription: "The URLs of the update keys.",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"encryption_keys": schema.ListAttribute{
+						MarkdownDescription: "The URLs of the encryption keys.",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"linked_domain_urls": schema.ListAttribute{
+						MarkdownDescription: "The domains linked to the DID.",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"did_document_status": schema.StringAttribute{
+						MarkdownDescription: "The publishing status of the DID document.",
+						Computed:            true,
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the authority, for example `Enabled`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"disable_etag": schema.BoolAttribute{
+				MarkdownDescription: docstrings.DisableETag(),
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *VerifiedIDAuthorityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDAuthorityResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model *VerifiedIDAuthorityResourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.KeyStore.IsUnknown() || model.KeyVaultMetadata.IsUnknown() {
+		return
+	}
+
+	switch {
+	case model.KeyStore.ValueString() == keyStoreKeyVault && model.KeyVaultMetadata.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key_vault_metadata"),
+			"Missing key_vault_metadata",
+			"`key_vault_metadata` must be specified when `key_store` is `KeyVault`.",
+		)
+	case model.KeyStore.ValueString() != keyStoreKeyVault && !model.KeyVaultMetadata.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("key_vault_metadata"),
+			"Unexpected key_vault_metadata",
+			"`key_vault_metadata` can only be specified when `key_store` is `KeyVault`.",
+		)
+	}
+}
+
+func (r *VerifiedIDAuthorityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *VerifiedIDAuthorityResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// An authority is created with a single linked domain, the others are linked once it exists.
+	linkedDomainUrls := AsListOfString(model.LinkedDomainUrls)
+	requestBody := map[string]interface{}{
+		"name":            model.Name.ValueString(),
+		"linkedDomainUrl": linkedDomainUrls[0],
+		"didMethod":       model.DidMethod.ValueString(),
+	}
+	if model.KeyStore.ValueString() == keyStoreKeyVault {
+		var keyVaultMetadata keyVaultMetadataModel
+		if resp.Diagnostics.Append(model.KeyVaultMetadata.As(ctx, &keyVaultMetadata, basetypes.ObjectAsOptions{})...); resp.Diagnostics.HasError() {
+			return
+		}
+		requestBody["keyVaultMetadata"] = keyVaultMetadataApiModel{
+			SubscriptionId: keyVaultMetadata.SubscriptionId.ValueString(),
+			ResourceGroup:  keyVaultMetadata.ResourceGroup.ValueString(),
+			ResourceName:   keyVaultMetadata.ResourceName.ValueString(),
+			ResourceUrl:    keyVaultMetadata.ResourceUrl.ValueString(),
+		}
+	} else {
+		requestBody["keyStore"] = model.KeyStore.ValueString()
+	}
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationCreate),
+	}
+	responseBody, err := r.client.Create(ctx, authoritiesUrl(), verifiedIDApiVersion, requestBody, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to create authority", err)
+		return
+	}
+
+	var created authorityApiModel
+	if err := decodeResponseBody(responseBody, &created); err != nil {
+		resp.Diagnostics.AddError("Failed to decode authority", err.Error())
+		return
+	}
+	if created.Id == "" {
+		resp.Diagnostics.AddError("Failed to create authority", "The response did not contain the ID of the authority.")
+		return
+	}
+	model.Id = types.StringValue(created.Id)
+
+	if len(linkedDomainUrls) > 1 {
+		if _, err := r.client.Action(ctx, http.MethodPost, updateLinkedDomainsUrl(created.Id), verifiedIDApiVersion, updateLinkedDomainsApiModel{DomainUrls: linkedDomainUrls}, options); err != nil {
+			addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to update linked domains", err)
+			return
+		}
+	}
+
+	options = clients.RequestOptions{
+		RetryOptions: clients.CombineRetryOptions(
+			clients.NewRetryOptionsForReadAfterCreate(),
+			r.client.RetryOptions(model.Retry, retry.OperationCreate),
+		),
+	}
+	responseBody, etag, err := readWithETag(ctx, r.client, authorityUrl(model.Id.ValueString()), verifiedIDApiVersion, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read authority", err)
+		return
+	}
+	if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.Append(model.flatten(ctx, responseBody)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDAuthorityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model *VerifiedIDAuthorityResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	responseBody, etag, err := readWithETag(ctx, r.client, authorityUrl(model.Id.ValueString()), verifiedIDApiVersion, options)
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Authority %q was not found - removing from state", model.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read authority", err)
+		return
+	}
+	if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.Append(model.flatten(ctx, responseBody)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDAuthorityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model, state *VerifiedIDAuthorityResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := model.Timeouts.Update(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Only the name and the linked domains of an authority can be changed in place, the name with a PATCH and the
+	// linked domains with the `updateLinkedDomains` action.
+	if !model.Name.Equal(state.Name) {
+		requestBody := map[string]interface{}{
+			"name": model.Name.ValueString(),
+		}
+		etag, diags := getETag(ctx, req.Private)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+		options := clients.RequestOptions{
+			Headers:      ifMatchHeaders(etag, model.DisableETag.ValueBool()),
+			RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationUpdate),
+		}
+		if _, err := r.client.Update(ctx, authorityUrl(model.Id.ValueString()), verifiedIDApiVersion, requestBody, options); err != nil {
+			addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to update authority", err)
+			return
+		}
+	}
+	if !model.LinkedDomainUrls.Equal(state.LinkedDomainUrls) {
+		options := clients.RequestOptions{
+			RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationUpdate),
+		}
+		requestBody := updateLinkedDomainsApiModel{DomainUrls: AsListOfString(model.LinkedDomainUrls)}
+		if _, err := r.client.Action(ctx, http.MethodPost, updateLinkedDomainsUrl(model.Id.ValueString()), verifiedIDApiVersion, requestBody, options); err != nil {
+			addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to update linked domains", err)
+			return
+		}
+	}
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationUpdate),
+	}
+	responseBody, etag, err := readWithETag(ctx, r.client, authorityUrl(model.Id.ValueString()), verifiedIDApiVersion, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read authority", err)
+		return
+	}
+	if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.Append(model.flatten(ctx, responseBody)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDAuthorityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model *VerifiedIDAuthorityResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationDelete),
+	}
+	if err := r.client.Delete(ctx, authorityUrl(model.Id.ValueString()), verifiedIDApiVersion, options); err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			return
+		}
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to delete authority", err)
+		return
+	}
+}
+
+func (r *VerifiedIDAuthorityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	model := &VerifiedIDAuthorityResourceModel{
+		Id:               types.StringValue(req.ID),
+		LinkedDomainUrls: types.ListNull(types.StringType),
+		KeyVaultMetadata: types.ObjectNull(keyVaultMetadataAttributeTypes),
+		DidModel:         types.ObjectNull(didModelAttributeTypes),
+		DisableETag:      types.BoolValue(false),
+		Retry:            retry.NewValueNull(),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"update": types.StringType,
+				"read":   types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// flatten maps the authority returned by the API onto the model.
+func (model *VerifiedIDAuthorityResourceModel) flatten(ctx context.Context, responseBody interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var authority authorityApiModel
+	if err := decodeResponseBody(responseBody, &authority); err != nil {
+		diags.AddError("Failed to decode authority", err.Error())
+		return diags
+	}
+
+	if authority.Id != "" {
+		model.Id = types.StringValue(authority.Id)
+	}
+	model.Name = types.StringValue(authority.Name)
+	model.Status = types.StringValue(authority.Status)
+
+	didModel := authority.DidModel
+	if didModel == nil {
+		didModel = &authorityDidModelApiModel{}
+	}
+	model.Did = types.StringValue(didModel.Did)
+	if strings.HasPrefix(didModel.Did, "did:") {
+		model.DidMethod = types.StringValue(strings.Split(didModel.Did, ":")[1])
+	}
+	model.LinkedDomainUrls = stringListValue(didModel.LinkedDomainUrls)
+
+	didModelValue, d := types.ObjectValueFrom(ctx, didModelAttributeTypes, map[string]interface{}{
+		"did":                 didModel.Did,
+		"signing_keys":        stringsOrEmpty(didModel.SigningKeys),
+		"recovery_keys":       stringsOrEmpty(didModel.RecoveryKeys),
+		"update_keys":         stringsOrEmpty(didModel.UpdateKeys),
+		"encryption_keys":     stringsOrEmpty(didModel.EncryptionKeys),
+		"linked_domain_urls":  stringsOrEmpty(didModel.LinkedDomainUrls),
+		"did_document_status": didModel.DidDocumentStatus,
+	})
+	diags.Append(d...)
+	model.DidModel = didModelValue
+
+	// The API doesn't return the key store, it is derived from the presence of the Key Vault metadata.
+	if model.KeyStore.IsNull() || model.KeyStore.IsUnknown() {
+		model.KeyStore = types.StringValue(keyStoreManaged)
+		if authority.KeyVaultMetadata != nil {
+			model.KeyStore = types.StringValue(keyStoreKeyVault)
+		}
+	}
+	if model.KeyStore.ValueString() == keyStoreKeyVault && authority.KeyVaultMetadata != nil {
+		keyVaultMetadata, d := types.ObjectValueFrom(ctx, keyVaultMetadataAttributeTypes, keyVaultMetadataModel{
+			SubscriptionId: types.StringValue(authority.KeyVaultMetadata.SubscriptionId),
+			ResourceGroup:  types.StringValue(authority.KeyVaultMetadata.ResourceGroup),
+			ResourceName:   types.StringValue(authority.KeyVaultMetadata.ResourceName),
+			ResourceUrl:    types.StringValue(authority.KeyVaultMetadata.ResourceUrl),
+		})
+		diags.Append(d...)
+		model.KeyVaultMetadata = keyVaultMetadata
+	} else {
+		model.KeyVaultMetadata = types.ObjectNull(keyVaultMetadataAttributeTypes)
+	}
+
+	return diags
+}
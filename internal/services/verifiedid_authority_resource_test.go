@@ -0,0 +1,221 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+type VerifiedIDAuthorityTestResource struct{}
+
+func TestAcc_AuthorityBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_authority", "test")
+
+	r := VerifiedIDAuthorityTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "Demo Authority"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("did").MatchesRegex(regexp.MustCompile(`^did:web:`)),
+				check.That(data.ResourceName).Key("did_method").HasValue("web"),
+				check.That(data.ResourceName).Key("key_store").HasValue("Managed"),
+				check.That(data.ResourceName).Key("status").Exists(),
+			),
+		},
+		data.ImportStep("retry"),
+	})
+}
+
+func TestAcc_AuthorityFakeServerThrottled(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_authority", "test")
+	data.FakeServer.InjectFault(acceptance.Fault{Method: http.MethodPost, Path: "authorities", StatusCode: http.StatusTooManyRequests, Count: 2})
+
+	r := VerifiedIDAuthorityTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "Demo Authority"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+	})
+}
+
+func TestAcc_AuthorityFakeServerConflict(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_authority", "test")
+	data.FakeServer.InjectFault(acceptance.Fault{Method: http.MethodPost, Path: "authorities", StatusCode: http.StatusConflict})
+
+	r := VerifiedIDAuthorityTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.basic(data, "Demo Authority"),
+			ExpectError: regexp.MustCompile(`Conflict`),
+		},
+	})
+}
+
+func TestAcc_AuthorityFakeServerLinkedDomainsRemovedOutsideTerraform(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_authority", "test")
+
+	r := VerifiedIDAuthorityTestResource{}
+
+	var authorityId string
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "Demo Authority"),
+			Check: func(s *terraform.State) error {
+				authorityId = s.RootModule().Resources[data.ResourceName].Primary.ID
+				return nil
+			},
+		},
+		{
+			PreConfig: func() {
+				if err := data.FakeServer.ChangeAuthorityDidModel(authorityId, "linkedDomainUrls", []interface{}{}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			Config:             r.basic(data, "Demo Authority"),
+			PlanOnly:           true,
+			ExpectNonEmptyPlan: true,
+		},
+	})
+}
+
+func TestAcc_AuthorityUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_authority", "test")
+
+	r := VerifiedIDAuthorityTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "Demo Authority"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("name").HasValue(fmt.Sprintf("Demo Authority %s", data.RandomString)),
+			),
+		},
+		data.ImportStep("retry"),
+		{
+			Config: r.basic(data, "Demo Authority Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("name").HasValue(fmt.Sprintf("Demo Authority Updated %s", data.RandomString)),
+			),
+		},
+		data.ImportStep("retry"),
+	})
+}
+
+func TestAcc_AuthorityFakeServerUpdateLinkedDomains(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_authority", "test")
+
+	r := VerifiedIDAuthorityTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "Demo Authority"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("linked_domain_urls.#").HasValue("1"),
+			),
+		},
+		{
+			Config: r.linkedDomains(data, "Demo Authority Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("name").HasValue(fmt.Sprintf("Demo Authority Updated %s", data.RandomString)),
+				check.That(data.ResourceName).Key("linked_domain_urls.#").HasValue("2"),
+				check.That(data.ResourceName).Key("linked_domain_urls.1").HasValue(fmt.Sprintf("https://%s.fabrikam.com/", data.RandomString)),
+			),
+		},
+	})
+}
+
+func TestAcc_AuthorityInvalidLinkedDomain(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_authority", "test")
+
+	r := VerifiedIDAuthorityTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.invalidLinkedDomain(),
+			ExpectError: regexp.MustCompile(`must be an absolute URL using the https scheme`),
+		},
+	})
+}
+
+func TestAcc_AuthorityKeyVaultMetadataRequired(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_authority", "test")
+
+	r := VerifiedIDAuthorityTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.keyVaultWithoutMetadata(),
+			ExpectError: regexp.MustCompile(`key_vault_metadata`),
+		},
+	})
+}
+
+func (r VerifiedIDAuthorityTestResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	_, err := client.VerifiedIDClient.Read(ctx, fmt.Sprintf("verifiableCredentials/authorities/%s", state.ID), "v1.0", clients.DefaultRequestOptions())
+	if err == nil {
+		b := true
+		return &b, nil
+	}
+	if utils.ResponseErrorWasNotFound(err) {
+		b := false
+		return &b, nil
+	}
+	return nil, fmt.Errorf("checking for presence of existing authority %s: %w", state.ID, err)
+}
+
+func (r VerifiedIDAuthorityTestResource) basic(data acceptance.TestData, name string) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "test" {
+  name               = "%s %s"
+  linked_domain_urls = ["https://%s.contoso.com/"]
+}
+`, name, data.RandomString, data.RandomString)
+}
+
+func (r VerifiedIDAuthorityTestResource) linkedDomains(data acceptance.TestData, name string) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "test" {
+  name               = "%s %s"
+  linked_domain_urls = ["https://%s.contoso.com/", "https://%s.fabrikam.com/"]
+}
+`, name, data.RandomString, data.RandomString, data.RandomString)
+}
+
+func (r VerifiedIDAuthorityTestResource) invalidLinkedDomain() string {
+	return `
+resource "verifiedid_authority" "test" {
+  name               = "Demo Authority"
+  linked_domain_urls = ["http://www.contoso.com/"]
+}
+`
+}
+
+func (r VerifiedIDAuthorityTestResource) keyVaultWithoutMetadata() string {
+	return `
+resource "verifiedid_authority" "test" {
+  name               = "Demo Authority"
+  linked_domain_urls = ["https://www.contoso.com/"]
+  key_store          = "KeyVault"
+}
+`
+}
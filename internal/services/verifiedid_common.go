@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// verifiedIDApiVersion is the Admin API version used by the typed Verified ID resources and data sources.
+const verifiedIDApiVersion = "v1.0"
+
+const (
+	didMethodWeb = "web"
+	didMethodIon = "ion"
+
+	keyStoreManaged  = "Managed"
+	keyStoreKeyVault = "KeyVault"
+)
+
+// tenantNotOnboardedErrorCode is the error code returned by the Admin API when the tenant isn't onboarded to Verified ID.
+const tenantNotOnboardedErrorCode = "TenantNotOnboarded"
+
+func onboardUrl() string {
+	return "verifiableCredentials/onboard"
+}
+
+func optOutUrl() string {
+	return "verifiableCredentials/optout"
+}
+
+func authoritiesUrl() string {
+	return "verifiableCredentials/authorities"
+}
+
+func authorityUrl(authorityId string) string {
+	return fmt.Sprintf("%s/%s", authoritiesUrl(), authorityId)
+}
+
+// authorityApiModel is the Verified ID Admin API representation of an authority.
+type authorityApiModel struct {
+	Id               string                     `json:"id,omitempty"`
+	Name             string                     `json:"name,omitempty"`
+	Status           string                     `json:"status,omitempty"`
+	KeyVaultMetadata *keyVaultMetadataApiModel  `json:"keyVaultMetadata,omitempty"`
+	DidModel         *authorityDidModelApiModel `json:"didModel,omitempty"`
+}
+
+// authorityListApiModel is a page of authorities as returned by MSGraphClient.List.
+type authorityListApiModel struct {
+	Value []authorityApiModel `json:"value"`
+}
+
+type keyVaultMetadataApiModel struct {
+	SubscriptionId string `json:"subscriptionId,omitempty"`
+	ResourceGroup  string `json:"resourceGroup,omitempty"`
+	ResourceName   string `json:"resourceName,omitempty"`
+	ResourceUrl    string `json:"resourceUrl,omitempty"`
+}
+
+// updateLinkedDomainsApiModel is the body of the `updateLinkedDomains` action, which replaces the linked domains of an
+// authority.
+type updateLinkedDomainsApiModel struct {
+	DomainUrls []string `json:"domainUrls"`
+}
+
+type authorityDidModelApiModel struct {
+	Did               string   `json:"did,omitempty"`
+	SigningKeys       []string `json:"signingKeys,omitempty"`
+	RecoveryKeys      []string `json:"recoveryKeys,omitempty"`
+	UpdateKeys        []string `json:"updateKeys,omitempty"`
+	EncryptionKeys    []string `json:"encryptionKeys,omitempty"`
+	LinkedDomainUrls  []string `json:"linkedDomainUrls,omitempty"`
+	DidDocumentStatus string   `json:"didDocumentStatus,omitempty"`
+
+	// LinkedDomainsVerified is set once the well-known DID configuration of the linked domains has been validated.
+	LinkedDomainsVerified bool `json:"linkedDomainsVerified,omitempty"`
+}
+
+// readAuthorityDidModel reads the authority and returns its DID model, which is empty if the service didn't return one.
+func readAuthorityDidModel(ctx context.Context, client *clients.VerifiedIDClient, authorityId string, options clients.RequestOptions) (*authorityDidModelApiModel, error) {
+	responseBody, err := client.Read(ctx, authorityUrl(authorityId), verifiedIDApiVersion, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var authority authorityApiModel
+	if err := decodeResponseBody(responseBody, &authority); err != nil {
+		return nil, err
+	}
+	if authority.DidModel == nil {
+		return &authorityDidModelApiModel{}, nil
+	}
+	return authority.DidModel, nil
+}
+
+// tenantIsOnboarded reports whether the tenant is onboarded to Verified ID, by listing its authorities.
+func tenantIsOnboarded(ctx context.Context, client *clients.VerifiedIDClient, options clients.RequestOptions) (bool, error) {
+	_, err := client.Read(ctx, authoritiesUrl(), verifiedIDApiVersion, options)
+	switch {
+	case err == nil:
+		return true, nil
+	case utils.ResponseErrorWasErrorCode(err, tenantNotOnboardedErrorCode):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// decodeResponseBody converts an untyped response body returned by the client into the given typed API model.
+func decodeResponseBody(body interface{}, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// stringsOrEmpty makes sure a missing list in the response is stored as an empty list rather than null.
+func stringsOrEmpty(input []string) []string {
+	if input == nil {
+		return []string{}
+	}
+	return input
+}
+
+func contractsUrl(authorityId string) string {
+	return fmt.Sprintf("%s/contracts", authorityUrl(authorityId))
+}
+
+func contractUrl(authorityId, contractId string) string {
+	return fmt.Sprintf("%s/%s", contractsUrl(authorityId), contractId)
+}
+
+func credentialsUrl(authorityId, contractId string) string {
+	return fmt.Sprintf("%s/credentials", contractUrl(authorityId, contractId))
+}
+
+func revokeCredentialUrl(authorityId, contractId, credentialId string) string {
+	return fmt.Sprintf("%s/%s/revoke", credentialsUrl(authorityId, contractId), credentialId)
+}
+
+// credentialsFilterQueryParameters returns the query parameters used to search the credentials of a contract by the
+// hash of their indexed claim, see utils.IndexClaimHash.
+func credentialsFilterQueryParameters(indexClaimHash string) map[string]string {
+	return map[string]string{
+		"filter": fmt.Sprintf("indexclaimhash eq %s", indexClaimHash),
+	}
+}
+
+// contractApiModel is the Verified ID Admin API representation of a contract (credential definition).
+type contractApiModel struct {
+	Id          string                    `json:"id,omitempty"`
+	Name        string                    `json:"name,omitempty"`
+	Status      string                    `json:"status,omitempty"`
+	ManifestUrl string                    `json:"manifestUrl,omitempty"`
+	Rules       *contractRulesApiModel    `json:"rules,omitempty"`
+	Displays    []contractDisplayApiModel `json:"displays,omitempty"`
+}
+
+// contractListApiModel is a page of contracts as returned by MSGraphClient.List.
+type contractListApiModel struct {
+	Value []contractApiModel `json:"value"`
+}
+
+type contractRulesApiModel struct {
+	Attestations     *contractAttestationsApiModel `json:"attestations,omitempty"`
+	ValidityInterval int64                         `json:"validityInterval,omitempty"`
+	Vc               *contractVcApiModel           `json:"vc,omitempty"`
+}
+
+type contractAttestationsApiModel struct {
+	IdTokens      []contractAttestationApiModel `json:"idTokens,omitempty"`
+	IdTokenHints  []contractAttestationApiModel `json:"idTokenHints,omitempty"`
+	AccessTokens  []contractAttestationApiModel `json:"accessTokens,omitempty"`
+	Presentations []contractAttestationApiModel `json:"presentations,omitempty"`
+	SelfIssued    *contractAttestationApiModel  `json:"selfIssued,omitempty"`
+}
+
+// contractAttestationApiModel covers all attestation types, the type specific fields are omitted when empty.
+type contractAttestationApiModel struct {
+	Mapping  []contractClaimMappingApiModel `json:"mapping,omitempty"`
+	Required bool                           `json:"required"`
+
+	// idTokens
+	Configuration string `json:"configuration,omitempty"`
+	ClientId      string `json:"clientId,omitempty"`
+	RedirectUri   string `json:"redirectUri,omitempty"`
+	Scope         string `json:"scope,omitempty"`
+
+	// presentations
+	CredentialType string   `json:"credentialType,omitempty"`
+	TrustedIssuers []string `json:"trustedIssuers,omitempty"`
+}
+
+type contractClaimMappingApiModel struct {
+	InputClaim  string `json:"inputClaim"`
+	OutputClaim string `json:"outputClaim"`
+	Indexed     bool   `json:"indexed"`
+	Required    bool   `json:"required"`
+}
+
+type contractVcApiModel struct {
+	Type []string `json:"type"`
+}
+
+type contractDisplayApiModel struct {
+	Locale  string                         `json:"locale"`
+	Card    contractCardApiModel           `json:"card"`
+	Consent contractConsentApiModel        `json:"consent"`
+	Claims  []contractDisplayClaimApiModel `json:"claims"`
+}
+
+type contractCardApiModel struct {
+	Title           string                `json:"title"`
+	IssuedBy        string                `json:"issuedBy"`
+	BackgroundColor string                `json:"backgroundColor"`
+	TextColor       string                `json:"textColor"`
+	Description     string                `json:"description,omitempty"`
+	Logo            *contractLogoApiModel `json:"logo,omitempty"`
+}
+
+type contractLogoApiModel struct {
+	Uri         string `json:"uri"`
+	Description string `json:"description,omitempty"`
+}
+
+type contractConsentApiModel struct {
+	Title        string `json:"title,omitempty"`
+	Instructions string `json:"instructions,omitempty"`
+}
+
+type contractDisplayClaimApiModel struct {
+	Claim       string `json:"claim"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// stringValueOrNull stores an empty optional string returned by the API as null, so it matches an omitted attribute.
+// stringListValue converts the strings into a list, nil is converted into an empty list.
+func stringListValue(input []string) types.List {
+	values := make([]attr.Value, 0, len(input))
+	for _, v := range input {
+		values = append(values, types.StringValue(v))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+func stringValueOrNull(input string) types.String {
+	if input == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(input)
+}
+
+// isFullyKnown reports whether the value and all of its nested values are known, which is required before a
+// nested value can be converted into a Go struct during validation.
+func isFullyKnown(ctx context.Context, value attr.Value) bool {
+	v, err := value.ToTerraformValue(ctx)
+	return err == nil && v.IsFullyKnown()
+}
+
+func validateWellKnownDidConfigurationUrl(authorityId string) string {
+	return fmt.Sprintf("%s/validateWellKnownDidConfiguration", authorityUrl(authorityId))
+}
+
+func updateLinkedDomainsUrl(authorityId string) string {
+	return fmt.Sprintf("%s/updateLinkedDomains", authorityUrl(authorityId))
+}
+
+func rotateSigningKeyUrl(authorityId string) string {
+	return fmt.Sprintf("%s/didInfo/signingKeys/rotate", authorityUrl(authorityId))
+}
+
+func synchronizeWithDidDocumentUrl(authorityId string) string {
+	return fmt.Sprintf("%s/didInfo/synchronizeWithDidDocument", authorityUrl(authorityId))
+}
+
+func generateDidDocumentUrl(authorityId string) string {
+	return fmt.Sprintf("%s/generateDidDocument", authorityUrl(authorityId))
+}
+
+func generateWellknownDidConfigurationUrl(authorityId string) string {
+	return fmt.Sprintf("%s/generateWellknownDidConfiguration", authorityUrl(authorityId))
+}
+
+// didDocumentApiModel is the subset of a DID document used by the typed attributes of the DID document data source.
+type didDocumentApiModel struct {
+	Id                 string                          `json:"id"`
+	VerificationMethod []didVerificationMethodApiModel `json:"verificationMethod"`
+	Service            []didServiceApiModel            `json:"service"`
+}
+
+type didVerificationMethodApiModel struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// didServiceApiModel is a service of a DID document, the endpoint is either a URL or an object such as
+// `{"origins": [...]}` for the LinkedDomains service.
+type didServiceApiModel struct {
+	Id              string      `json:"id"`
+	Type            string      `json:"type"`
+	ServiceEndpoint interface{} `json:"serviceEndpoint"`
+}
+
+// didConfigurationApiModel is the well-known DID configuration, see https://identity.foundation/.well-known/resources/did-configuration/.
+type didConfigurationApiModel struct {
+	LinkedDids []string `json:"linked_dids"`
+}
+
+// credentialStatusRevoked is the status of a revoked credential.
+const credentialStatusRevoked = "revoked"
+
+// credentialApiModel is the Verified ID Admin API representation of an issued credential.
+type credentialApiModel struct {
+	Id        string `json:"id"`
+	Status    string `json:"status,omitempty"`
+	IssuedAt  string `json:"issuedAt,omitempty"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+}
+
+// credentialListApiModel is a page of credentials as returned by the credentials search.
+type credentialListApiModel struct {
+	Value []credentialApiModel `json:"value"`
+}
@@ -0,0 +1,1071 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/docstrings"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &VerifiedIDContractResource{}
+	_ resource.ResourceWithImportState    = &VerifiedIDContractResource{}
+	_ resource.ResourceWithValidateConfig = &VerifiedIDContractResource{}
+)
+
+// contractClaimPrefix is the prefix of the display claims, they reference the output claims of the credential subject.
+const contractClaimPrefix = "vc.credentialSubject."
+
+var hexColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func NewVerifiedIDContractResource() resource.Resource {
+	return &VerifiedIDContractResource{}
+}
+
+// VerifiedIDContractResource defines the resource implementation.
+type VerifiedIDContractResource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDContractResourceModel describes the resource data model.
+type VerifiedIDContractResourceModel struct {
+	Id          types.String   `tfsdk:"id"`
+	AuthorityId types.String   `tfsdk:"authority_id"`
+	Name        types.String   `tfsdk:"name"`
+	Rules       types.Object   `tfsdk:"rules"`
+	Displays    types.Map      `tfsdk:"displays"`
+	ManifestUrl types.String   `tfsdk:"manifest_url"`
+	Status      types.String   `tfsdk:"status"`
+	DisableETag types.Bool     `tfsdk:"disable_etag"`
+	Retry       retry.Value    `tfsdk:"retry"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+type contractRulesModel struct {
+	Attestations     contractAttestationsModel `tfsdk:"attestations"`
+	ValidityInterval types.Int64               `tfsdk:"validity_interval"`
+	Vc               contractVcModel           `tfsdk:"vc"`
+}
+
+type contractAttestationsModel struct {
+	IdTokens      []contractIdTokenAttestationModel      `tfsdk:"id_tokens"`
+	IdTokenHints  []contractAttestationModel             `tfsdk:"id_token_hints"`
+	AccessTokens  []contractAttestationModel             `tfsdk:"access_tokens"`
+	Presentations []contractPresentationAttestationModel `tfsdk:"presentations"`
+	SelfIssued    *contractAttestationModel              `tfsdk:"self_issued"`
+}
+
+type contractAttestationModel struct {
+	Mapping  []contractClaimMappingModel `tfsdk:"mapping"`
+	Required types.Bool                  `tfsdk:"required"`
+}
+
+type contractIdTokenAttestationModel struct {
+	Configuration types.String                `tfsdk:"configuration"`
+	ClientId      types.String                `tfsdk:"client_id"`
+	RedirectUri   types.String                `tfsdk:"redirect_uri"`
+	Scope         types.String                `tfsdk:"scope"`
+	Mapping       []contractClaimMappingModel `tfsdk:"mapping"`
+	Required      types.Bool                  `tfsdk:"required"`
+}
+
+type contractPresentationAttestationModel struct {
+	CredentialType types.String                `tfsdk:"credential_type"`
+	TrustedIssuers []string                    `tfsdk:"trusted_issuers"`
+	Mapping        []contractClaimMappingModel `tfsdk:"mapping"`
+	Required       types.Bool                  `tfsdk:"required"`
+}
+
+type contractClaimMappingModel struct {
+	InputClaim  types.String `tfsdk:"input_claim"`
+	OutputClaim types.String `tfsdk:"output_claim"`
+	Indexed     types.Bool   `tfsdk:"indexed"`
+	Required    types.Bool   `tfsdk:"required"`
+}
+
+type contractVcModel struct {
+	Type []string `tfsdk:"type"`
+}
+
+type contractDisplayModel struct {
+	Card    contractCardModel           `tfsdk:"card"`
+	Consent contractConsentModel        `tfsdk:"consent"`
+	Claims  []contractDisplayClaimModel `tfsdk:"claims"`
+}
+
+type contractCardModel struct {
+	Title           types.String       `tfsdk:"title"`
+	IssuedBy        types.String       `tfsdk:"issued_by"`
+	BackgroundColor types.String       `tfsdk:"background_color"`
+	TextColor       types.String       `tfsdk:"text_color"`
+	Description     types.String       `tfsdk:"description"`
+	Logo            *contractLogoModel `tfsdk:"logo"`
+}
+
+type contractLogoModel struct {
+	Uri         types.String `tfsdk:"uri"`
+	Description types.String `tfsdk:"description"`
+}
+
+type contractConsentModel struct {
+	Title        types.String `tfsdk:"title"`
+	Instructions types.String `tfsdk:"instructions"`
+}
+
+type contractDisplayClaimModel struct {
+	Claim       types.String `tfsdk:"claim"`
+	Label       types.String `tfsdk:"label"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (r *VerifiedIDContractResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contract"
+}
+
+func (r *VerifiedIDContractResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Microsoft Entra Verified ID contract (credential definition) of an authority.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the contract.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"authority_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority which issues the credentials of the contract. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the contract. It must be unique within the tenant.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"rules": contractRulesAttribute(),
+
+			"displays": contractDisplaysAttribute(),
+
+			"manifest_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the manifest of the contract, used when issuing credentials.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the contract, for example `Enabled`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"disable_etag": schema.BoolAttribute{
+				MarkdownDescription: docstrings.DisableETag(),
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func contractRulesAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "The rules of the contract, which describe how the claims of the credential are collected.",
+		Required:            true,
+		Attributes: map[string]schema.Attribute{
+			"attestations": schema.SingleNestedAttribute{
+				MarkdownDescription: "The attestations which provide the claims of the credential. At least one attestation must be specified.",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"id_tokens": schema.ListNestedAttribute{
+						MarkdownDescription: "Claims taken from an ID token issued by an OpenID Connect identity provider.",
+						Optional:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: contractAttestationAttributes(map[string]schema.Attribute{
+								"configuration": schema.StringAttribute{
+									MarkdownDescription: "The URL of the OpenID Connect configuration document of the identity provider.",
+									Required:            true,
+									Validators: []validator.String{
+										myvalidator.StringIsHttpsURL(),
+									},
+								},
+								"client_id": schema.StringAttribute{
+									MarkdownDescription: "The client ID used to sign in to the identity provider.",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+								"redirect_uri": schema.StringAttribute{
+									MarkdownDescription: "The redirect URI used when signing in to the identity provider, for example `vcclient://openid`.",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+								"scope": schema.StringAttribute{
+									MarkdownDescription: "A space separated list of scopes requested from the identity provider.",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+							}),
+						},
+					},
+					"id_token_hints": schema.ListNestedAttribute{
+						MarkdownDescription: "Claims provided by the relying party application in the issuance request.",
+						Optional:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: contractAttestationAttributes(nil),
+						},
+					},
+					"access_tokens": schema.ListNestedAttribute{
+						MarkdownDescription: "Claims taken from an access token.",
+						Optional:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: contractAttestationAttributes(nil),
+						},
+					},
+					"presentations": schema.ListNestedAttribute{
+						MarkdownDescription: "Claims taken from another verifiable credential presented by the holder.",
+						Optional:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: contractAttestationAttributes(map[string]schema.Attribute{
+								"credential_type": schema.StringAttribute{
+									MarkdownDescription: "The type of the credential which must be presented.",
+									Required:            true,
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+								"trusted_issuers": schema.ListAttribute{
+									MarkdownDescription: "The DIDs of the issuers trusted to issue the presented credential.",
+									ElementType:         types.StringType,
+									Required:            true,
+									Validators: []validator.List{
+										listvalidator.SizeAtLeast(1),
+										listvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^did:`), "must be a DID, for example `did:web:contoso.com`")),
+									},
+								},
+							}),
+						},
+					},
+					"self_issued": schema.SingleNestedAttribute{
+						MarkdownDescription: "Claims entered by the holder in the wallet.",
+						Optional:            true,
+						Attributes:          contractAttestationAttributes(nil),
+					},
+				},
+			},
+			"validity_interval": schema.Int64Attribute{
+				MarkdownDescription: "The lifespan of the issued credentials in seconds, for example `2592000` for 30 days.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"vc": schema.SingleNestedAttribute{
+				MarkdownDescription: "The verifiable credential issued by the contract.",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.ListAttribute{
+						MarkdownDescription: "The types of the verifiable credential, for example `[\"VerifiedEmployee\"]`.",
+						ElementType:         types.StringType,
+						Required:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.UniqueValues(),
+							listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// contractAttestationAttributes returns the attributes shared by all attestation types merged with the type specific ones.
+func contractAttestationAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	result := map[string]schema.Attribute{
+		"mapping": schema.ListNestedAttribute{
+			MarkdownDescription: "The mapping of the claims of the attestation to the claims of the credential.",
+			Required:            true,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+			},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"input_claim": schema.StringAttribute{
+						MarkdownDescription: "The name of the claim in the attestation.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"output_claim": schema.StringAttribute{
+						MarkdownDescription: "The name of the claim in the credential.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"indexed": schema.BoolAttribute{
+						MarkdownDescription: "Whether the value of the claim is indexed, which allows the credential to be found and revoked. Only one claim of a contract can be indexed. Defaults to `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"required": schema.BoolAttribute{
+						MarkdownDescription: "Whether the claim must be present. Defaults to `false`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+		},
+		"required": schema.BoolAttribute{
+			MarkdownDescription: "Whether the attestation must be provided during issuance. Defaults to `false`.",
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+		},
+	}
+	for k, v := range attributes {
+		result[k] = v
+	}
+	return result
+}
+
+func contractDisplaysAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		MarkdownDescription: "The way the credential is displayed in the wallet, keyed by locale, for example `en-US`.",
+		Required:            true,
+		Validators: []validator.Map{
+			mapvalidator.SizeAtLeast(1),
+			mapvalidator.KeysAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`), "must be a locale, for example `en-US`")),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"card": schema.SingleNestedAttribute{
+					MarkdownDescription: "The card shown in the wallet.",
+					Required:            true,
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{
+							MarkdownDescription: "The title of the credential.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"issued_by": schema.StringAttribute{
+							MarkdownDescription: "The name of the issuer of the credential.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"background_color": schema.StringAttribute{
+							MarkdownDescription: "The background color of the card in hex format, for example `#BDD0A7`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(hexColorRegex, "must be a color in hex format, for example `#000000`"),
+							},
+						},
+						"text_color": schema.StringAttribute{
+							MarkdownDescription: "The text color of the card in hex format, for example `#000000`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(hexColorRegex, "must be a color in hex format, for example `#000000`"),
+							},
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Supplemental text displayed alongside the card.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"logo": schema.SingleNestedAttribute{
+							MarkdownDescription: "The logo shown on the card.",
+							Optional:            true,
+							Attributes: map[string]schema.Attribute{
+								"uri": schema.StringAttribute{
+									MarkdownDescription: "The URL of the logo.",
+									Required:            true,
+									Validators: []validator.String{
+										myvalidator.StringIsHttpsURL(),
+									},
+								},
+								"description": schema.StringAttribute{
+									MarkdownDescription: "The description of the logo.",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+							},
+						},
+					},
+				},
+				"consent": schema.SingleNestedAttribute{
+					MarkdownDescription: "The text shown when the holder is asked to accept the credential.",
+					Required:            true,
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{
+							MarkdownDescription: "The title of the consent page.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"instructions": schema.StringAttribute{
+							MarkdownDescription: "Supplemental text shown on the consent page.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+				"claims": schema.ListNestedAttribute{
+					MarkdownDescription: "The labels of the claims shown in the wallet.",
+					Optional:            true,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+					},
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"claim": schema.StringAttribute{
+								MarkdownDescription: "The claim of the credential, for example `vc.credentialSubject.displayName`. It must reference an `output_claim` of the rules.",
+								Required:            true,
+								Validators: []validator.String{
+									stringvalidator.RegexMatches(regexp.MustCompile(`^`+regexp.QuoteMeta(contractClaimPrefix)+`.+`), "must start with `"+contractClaimPrefix+"`"),
+								},
+							},
+							"label": schema.StringAttribute{
+								MarkdownDescription: "The label of the claim.",
+								Required:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+							"type": schema.StringAttribute{
+								MarkdownDescription: "The type of the claim. Defaults to `String`.",
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString("String"),
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+							"description": schema.StringAttribute{
+								MarkdownDescription: "The description of the claim.",
+								Optional:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func contractRulesAttributeTypes() map[string]attr.Type {
+	return contractRulesAttribute().GetType().(types.ObjectType).AttrTypes
+}
+
+func contractDisplayElementType() attr.Type {
+	return contractDisplaysAttribute().GetType().(types.MapType).ElemType
+}
+
+func (r *VerifiedIDContractResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDContractResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model *VerifiedIDContractResourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Rules.IsNull() || !isFullyKnown(ctx, model.Rules) {
+		return
+	}
+	var rules contractRulesModel
+	if resp.Diagnostics.Append(model.Rules.As(ctx, &rules, basetypes.ObjectAsOptions{})...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	attestationsPath := path.Root("rules").AtName("attestations")
+	mappings := rules.Attestations.mappings()
+	if len(mappings) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			attestationsPath,
+			"Missing attestation",
+			"At least one of `id_tokens`, `id_token_hints`, `access_tokens`, `presentations` or `self_issued` must be specified.",
+		)
+		return
+	}
+
+	outputClaims := make(map[string]bool)
+	indexedClaims := make([]string, 0)
+	for _, mapping := range mappings {
+		outputClaims[mapping.OutputClaim.ValueString()] = true
+		if mapping.Indexed.ValueBool() {
+			indexedClaims = append(indexedClaims, mapping.OutputClaim.ValueString())
+		}
+	}
+	if len(indexedClaims) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			attestationsPath,
+			"Too many indexed claims",
+			fmt.Sprintf("Only one claim of a contract can be indexed, got %d: %s.", len(indexedClaims), strings.Join(indexedClaims, ", ")),
+		)
+	}
+
+	if model.Displays.IsNull() || !isFullyKnown(ctx, model.Displays) {
+		return
+	}
+	displays := make(map[string]contractDisplayModel)
+	if resp.Diagnostics.Append(model.Displays.ElementsAs(ctx, &displays, false)...); resp.Diagnostics.HasError() {
+		return
+	}
+	for locale, display := range displays {
+		for i, claim := range display.Claims {
+			outputClaim := strings.TrimPrefix(claim.Claim.ValueString(), contractClaimPrefix)
+			if !outputClaims[outputClaim] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("displays").AtMapKey(locale).AtName("claims").AtListIndex(i).AtName("claim"),
+					"Unknown claim",
+					fmt.Sprintf("The claim %q does not reference an `output_claim` of the rules.", claim.Claim.ValueString()),
+				)
+			}
+		}
+	}
+}
+
+func (r *VerifiedIDContractResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *VerifiedIDContractResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	requestBody, diags := model.expand(ctx)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationCreate),
+	}
+	responseBody, err := r.client.Create(ctx, contractsUrl(model.AuthorityId.ValueString()), verifiedIDApiVersion, requestBody, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to create contract", err)
+		return
+	}
+
+	var created contractApiModel
+	if err := decodeResponseBody(responseBody, &created); err != nil {
+		resp.Diagnostics.AddError("Failed to decode contract", err.Error())
+		return
+	}
+	if created.Id == "" {
+		resp.Diagnostics.AddError("Failed to create contract", "The response did not contain the ID of the contract.")
+		return
+	}
+	model.Id = types.StringValue(created.Id)
+
+	options = clients.RequestOptions{
+		RetryOptions: clients.CombineRetryOptions(
+			clients.NewRetryOptionsForReadAfterCreate(),
+			r.client.RetryOptions(model.Retry, retry.OperationCreate),
+		),
+	}
+	responseBody, etag, err := readWithETag(ctx, r.client, contractUrl(model.AuthorityId.ValueString(), model.Id.ValueString()), verifiedIDApiVersion, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read contract", err)
+		return
+	}
+	if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.Append(model.flatten(ctx, responseBody)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDContractResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model *VerifiedIDContractResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	responseBody, etag, err := readWithETag(ctx, r.client, contractUrl(model.AuthorityId.ValueString(), model.Id.ValueString()), verifiedIDApiVersion, options)
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Contract %q was not found - removing from state", model.Id.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read contract", err)
+		return
+	}
+	if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.Append(model.flatten(ctx, responseBody)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDContractResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model, state *VerifiedIDContractResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := model.Timeouts.Update(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if !model.Name.Equal(state.Name) || !model.Rules.Equal(state.Rules) || !model.Displays.Equal(state.Displays) {
+		requestBody, diags := model.expand(ctx)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+
+		etag, diags := getETag(ctx, req.Private)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+		options := clients.RequestOptions{
+			Headers:      ifMatchHeaders(etag, model.DisableETag.ValueBool()),
+			RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationUpdate),
+		}
+		if _, err := r.client.Update(ctx, contractUrl(model.AuthorityId.ValueString(), model.Id.ValueString()), verifiedIDApiVersion, requestBody, options); err != nil {
+			addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to update contract", err)
+			return
+		}
+	} else {
+		tflog.Info(ctx, "No changes detected in contract, skipping update")
+	}
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationUpdate),
+	}
+	responseBody, etag, err := readWithETag(ctx, r.client, contractUrl(model.AuthorityId.ValueString(), model.Id.ValueString()), verifiedIDApiVersion, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read contract", err)
+		return
+	}
+	if resp.Diagnostics.Append(setETag(ctx, resp.Private, etag)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if resp.Diagnostics.Append(model.flatten(ctx, responseBody)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDContractResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model *VerifiedIDContractResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationDelete),
+	}
+	if err := r.client.Delete(ctx, contractUrl(model.AuthorityId.ValueString(), model.Id.ValueString()), verifiedIDApiVersion, options); err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			return
+		}
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to delete contract", err)
+		return
+	}
+}
+
+func (r *VerifiedIDContractResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(strings.Trim(req.ID, "/"), "/")
+	if len(parts) != 5 || parts[0] != "verifiableCredentials" || parts[1] != "authorities" || parts[3] != "contracts" || parts[2] == "" || parts[4] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID in the format `verifiableCredentials/authorities/{authorityId}/contracts/{contractId}`, got %q.", req.ID),
+		)
+		return
+	}
+
+	model := &VerifiedIDContractResourceModel{
+		Id:          types.StringValue(parts[4]),
+		AuthorityId: types.StringValue(parts[2]),
+		Rules:       types.ObjectNull(contractRulesAttributeTypes()),
+		Displays:    types.MapNull(contractDisplayElementType()),
+		DisableETag: types.BoolValue(false),
+		Retry:       retry.NewValueNull(),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"update": types.StringType,
+				"read":   types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// mappings returns the claim mappings of all configured attestations.
+func (a contractAttestationsModel) mappings() []contractClaimMappingModel {
+	result := make([]contractClaimMappingModel, 0)
+	for _, v := range a.IdTokens {
+		result = append(result, v.Mapping...)
+	}
+	for _, v := range a.IdTokenHints {
+		result = append(result, v.Mapping...)
+	}
+	for _, v := range a.AccessTokens {
+		result = append(result, v.Mapping...)
+	}
+	for _, v := range a.Presentations {
+		result = append(result, v.Mapping...)
+	}
+	if a.SelfIssued != nil {
+		result = append(result, a.SelfIssued.Mapping...)
+	}
+	return result
+}
+
+// expand builds the request body of the contract from the model.
+func (model *VerifiedIDContractResourceModel) expand(ctx context.Context) (*contractApiModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var rules contractRulesModel
+	if diags.Append(model.Rules.As(ctx, &rules, basetypes.ObjectAsOptions{})...); diags.HasError() {
+		return nil, diags
+	}
+	displays := make(map[string]contractDisplayModel)
+	if diags.Append(model.Displays.ElementsAs(ctx, &displays, false)...); diags.HasError() {
+		return nil, diags
+	}
+
+	attestations := &contractAttestationsApiModel{}
+	for _, v := range rules.Attestations.IdTokens {
+		attestations.IdTokens = append(attestations.IdTokens, contractAttestationApiModel{
+			Configuration: v.Configuration.ValueString(),
+			ClientId:      v.ClientId.ValueString(),
+			RedirectUri:   v.RedirectUri.ValueString(),
+			Scope:         v.Scope.ValueString(),
+			Mapping:       expandContractClaimMappings(v.Mapping),
+			Required:      v.Required.ValueBool(),
+		})
+	}
+	for _, v := range rules.Attestations.IdTokenHints {
+		attestations.IdTokenHints = append(attestations.IdTokenHints, v.expand())
+	}
+	for _, v := range rules.Attestations.AccessTokens {
+		attestations.AccessTokens = append(attestations.AccessTokens, v.expand())
+	}
+	for _, v := range rules.Attestations.Presentations {
+		attestations.Presentations = append(attestations.Presentations, contractAttestationApiModel{
+			CredentialType: v.CredentialType.ValueString(),
+			TrustedIssuers: v.TrustedIssuers,
+			Mapping:        expandContractClaimMappings(v.Mapping),
+			Required:       v.Required.ValueBool(),
+		})
+	}
+	if rules.Attestations.SelfIssued != nil {
+		selfIssued := rules.Attestations.SelfIssued.expand()
+		attestations.SelfIssued = &selfIssued
+	}
+
+	// Displays are sorted by locale to keep the request body stable.
+	locales := make([]string, 0, len(displays))
+	for locale := range displays {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	displayList := make([]contractDisplayApiModel, 0, len(locales))
+	for _, locale := range locales {
+		display := displays[locale]
+		card := contractCardApiModel{
+			Title:           display.Card.Title.ValueString(),
+			IssuedBy:        display.Card.IssuedBy.ValueString(),
+			BackgroundColor: display.Card.BackgroundColor.ValueString(),
+			TextColor:       display.Card.TextColor.ValueString(),
+			Description:     display.Card.Description.ValueString(),
+		}
+		if display.Card.Logo != nil {
+			card.Logo = &contractLogoApiModel{
+				Uri:         display.Card.Logo.Uri.ValueString(),
+				Description: display.Card.Logo.Description.ValueString(),
+			}
+		}
+		claims := make([]contractDisplayClaimApiModel, 0, len(display.Claims))
+		for _, claim := range display.Claims {
+			claims = append(claims, contractDisplayClaimApiModel{
+				Claim:       claim.Claim.ValueString(),
+				Label:       claim.Label.ValueString(),
+				Type:        claim.Type.ValueString(),
+				Description: claim.Description.ValueString(),
+			})
+		}
+		displayList = append(displayList, contractDisplayApiModel{
+			Locale: locale,
+			Card:   card,
+			Consent: contractConsentApiModel{
+				Title:        display.Consent.Title.ValueString(),
+				Instructions: display.Consent.Instructions.ValueString(),
+			},
+			Claims: claims,
+		})
+	}
+
+	return &contractApiModel{
+		Name: model.Name.ValueString(),
+		Rules: &contractRulesApiModel{
+			Attestations:     attestations,
+			ValidityInterval: rules.ValidityInterval.ValueInt64(),
+			Vc: &contractVcApiModel{
+				Type: rules.Vc.Type,
+			},
+		},
+		Displays: displayList,
+	}, diags
+}
+
+func (a contractAttestationModel) expand() contractAttestationApiModel {
+	return contractAttestationApiModel{
+		Mapping:  expandContractClaimMappings(a.Mapping),
+		Required: a.Required.ValueBool(),
+	}
+}
+
+func expandContractClaimMappings(input []contractClaimMappingModel) []contractClaimMappingApiModel {
+	result := make([]contractClaimMappingApiModel, 0, len(input))
+	for _, v := range input {
+		result = append(result, contractClaimMappingApiModel{
+			InputClaim:  v.InputClaim.ValueString(),
+			OutputClaim: v.OutputClaim.ValueString(),
+			Indexed:     v.Indexed.ValueBool(),
+			Required:    v.Required.ValueBool(),
+		})
+	}
+	return result
+}
+
+// flatten maps the contract returned by the API onto the model.
+func (model *VerifiedIDContractResourceModel) flatten(ctx context.Context, responseBody interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var contract contractApiModel
+	if err := decodeResponseBody(responseBody, &contract); err != nil {
+		diags.AddError("Failed to decode contract", err.Error())
+		return diags
+	}
+
+	if contract.Id != "" {
+		model.Id = types.StringValue(contract.Id)
+	}
+	model.Name = types.StringValue(contract.Name)
+	model.ManifestUrl = types.StringValue(contract.ManifestUrl)
+	model.Status = types.StringValue(contract.Status)
+
+	// Empty lists returned by the API are stored as null, so they match omitted attributes.
+	rules := contractRulesModel{}
+	if contract.Rules != nil {
+		rules.ValidityInterval = types.Int64Value(contract.Rules.ValidityInterval)
+		if contract.Rules.Vc != nil && len(contract.Rules.Vc.Type) != 0 {
+			rules.Vc.Type = contract.Rules.Vc.Type
+		}
+		if attestations := contract.Rules.Attestations; attestations != nil {
+			for _, v := range attestations.IdTokens {
+				rules.Attestations.IdTokens = append(rules.Attestations.IdTokens, contractIdTokenAttestationModel{
+					Configuration: types.StringValue(v.Configuration),
+					ClientId:      types.StringValue(v.ClientId),
+					RedirectUri:   types.StringValue(v.RedirectUri),
+					Scope:         stringValueOrNull(v.Scope),
+					Mapping:       flattenContractClaimMappings(v.Mapping),
+					Required:      types.BoolValue(v.Required),
+				})
+			}
+			for _, v := range attestations.IdTokenHints {
+				rules.Attestations.IdTokenHints = append(rules.Attestations.IdTokenHints, flattenContractAttestation(v))
+			}
+			for _, v := range attestations.AccessTokens {
+				rules.Attestations.AccessTokens = append(rules.Attestations.AccessTokens, flattenContractAttestation(v))
+			}
+			for _, v := range attestations.Presentations {
+				presentation := contractPresentationAttestationModel{
+					CredentialType: types.StringValue(v.CredentialType),
+					Mapping:        flattenContractClaimMappings(v.Mapping),
+					Required:       types.BoolValue(v.Required),
+				}
+				if len(v.TrustedIssuers) != 0 {
+					presentation.TrustedIssuers = v.TrustedIssuers
+				}
+				rules.Attestations.Presentations = append(rules.Attestations.Presentations, presentation)
+			}
+			if attestations.SelfIssued != nil {
+				selfIssued := flattenContractAttestation(*attestations.SelfIssued)
+				rules.Attestations.SelfIssued = &selfIssued
+			}
+		}
+	}
+	rulesValue, d := types.ObjectValueFrom(ctx, contractRulesAttributeTypes(), rules)
+	diags.Append(d...)
+	model.Rules = rulesValue
+
+	displays := make(map[string]contractDisplayModel)
+	for _, v := range contract.Displays {
+		display := contractDisplayModel{
+			Card: contractCardModel{
+				Title:           types.StringValue(v.Card.Title),
+				IssuedBy:        types.StringValue(v.Card.IssuedBy),
+				BackgroundColor: types.StringValue(v.Card.BackgroundColor),
+				TextColor:       types.StringValue(v.Card.TextColor),
+				Description:     stringValueOrNull(v.Card.Description),
+			},
+			Consent: contractConsentModel{
+				Title:        types.StringValue(v.Consent.Title),
+				Instructions: stringValueOrNull(v.Consent.Instructions),
+			},
+		}
+		if v.Card.Logo != nil && v.Card.Logo.Uri != "" {
+			display.Card.Logo = &contractLogoModel{
+				Uri:         types.StringValue(v.Card.Logo.Uri),
+				Description: stringValueOrNull(v.Card.Logo.Description),
+			}
+		}
+		for _, claim := range v.Claims {
+			display.Claims = append(display.Claims, contractDisplayClaimModel{
+				Claim:       types.StringValue(claim.Claim),
+				Label:       types.StringValue(claim.Label),
+				Type:        types.StringValue(claim.Type),
+				Description: stringValueOrNull(claim.Description),
+			})
+		}
+		displays[v.Locale] = display
+	}
+	displaysValue, d := types.MapValueFrom(ctx, contractDisplayElementType(), displays)
+	diags.Append(d...)
+	model.Displays = displaysValue
+
+	return diags
+}
+
+func flattenContractAttestation(input contractAttestationApiModel) contractAttestationModel {
+	return contractAttestationModel{
+		Mapping:  flattenContractClaimMappings(input.Mapping),
+		Required: types.BoolValue(input.Required),
+	}
+}
+
+func flattenContractClaimMappings(input []contractClaimMappingApiModel) []contractClaimMappingModel {
+	var result []contractClaimMappingModel
+	for _, v := range input {
+		result = append(result, contractClaimMappingModel{
+			InputClaim:  types.StringValue(v.InputClaim),
+			OutputClaim: types.StringValue(v.OutputClaim),
+			Indexed:     types.BoolValue(v.Indexed),
+			Required:    types.BoolValue(v.Required),
+		})
+	}
+	return result
+}
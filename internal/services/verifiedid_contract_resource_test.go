@@ -0,0 +1,303 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+type VerifiedIDContractTestResource struct{}
+
+func TestAcc_ContractBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_contract", "test")
+
+	r := VerifiedIDContractTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "Demo Title"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").Exists(),
+				check.That(data.ResourceName).Key("manifest_url").MatchesRegex(regexp.MustCompile(`^https://`)),
+				check.That(data.ResourceName).Key("status").Exists(),
+				check.That(data.ResourceName).Key("rules.attestations.id_token_hints.0.mapping.0.indexed").HasValue("true"),
+				check.That(data.ResourceName).Key("rules.attestations.self_issued.mapping.0.indexed").HasValue("false"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, "retry"),
+	})
+}
+
+func TestAcc_ContractUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_contract", "test")
+
+	r := VerifiedIDContractTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "Demo Title"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("displays.en-US.card.title").HasValue("Demo Title"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, "retry"),
+		{
+			Config: r.basic(data, "Demo Title Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("displays.en-US.card.title").HasValue("Demo Title Updated"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, "retry"),
+	})
+}
+
+func TestAcc_ContractFakeServerChangedOutsideTerraform(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_contract", "test")
+
+	r := VerifiedIDContractTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "Demo Title"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("disable_etag").HasValue("false"),
+			),
+		},
+		{
+			PreConfig: func() {
+				data.FakeServer.InjectFault(acceptance.Fault{Method: http.MethodPatch, Path: "authorities", StatusCode: http.StatusPreconditionFailed, Count: 1})
+			},
+			Config:      r.basic(data, "Demo Title Updated"),
+			ExpectError: regexp.MustCompile(`resource changed outside Terraform`),
+		},
+		{
+			Config: r.basic(data, "Demo Title Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("displays.en-US.card.title").HasValue("Demo Title Updated"),
+			),
+		},
+	})
+}
+
+func TestAcc_ContractMultipleIndexedClaims(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_contract", "test")
+
+	r := VerifiedIDContractTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.multipleIndexedClaims(),
+			ExpectError: regexp.MustCompile(`Only one claim of a contract can be indexed`),
+		},
+	})
+}
+
+func TestAcc_ContractUnknownDisplayClaim(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_contract", "test")
+
+	r := VerifiedIDContractTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.unknownDisplayClaim(),
+			ExpectError: regexp.MustCompile(`does not reference an .output_claim.`),
+		},
+	})
+}
+
+func (r VerifiedIDContractTestResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	url := fmt.Sprintf("verifiableCredentials/authorities/%s/contracts/%s", state.Attributes["authority_id"], state.ID)
+	_, err := client.VerifiedIDClient.Read(ctx, url, "v1.0", clients.DefaultRequestOptions())
+	if err == nil {
+		b := true
+		return &b, nil
+	}
+	if utils.ResponseErrorWasNotFound(err) {
+		b := false
+		return &b, nil
+	}
+	return nil, fmt.Errorf("checking for presence of existing contract %s: %w", state.ID, err)
+}
+
+func (r VerifiedIDContractTestResource) ImportIdFunc(tfState *terraform.State) (string, error) {
+	state := tfState.RootModule().Resources["verifiedid_contract.test"].Primary
+	return fmt.Sprintf("verifiableCredentials/authorities/%s/contracts/%s", state.Attributes["authority_id"], state.ID), nil
+}
+
+func (r VerifiedIDContractTestResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "test" {
+  name               = "Demo Authority %s"
+  linked_domain_urls = ["https://%s.contoso.com/"]
+}
+`, data.RandomString, data.RandomString)
+}
+
+func (r VerifiedIDContractTestResource) basic(data acceptance.TestData, title string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "verifiedid_contract" "test" {
+  authority_id = verifiedid_authority.test.id
+  name         = "DemoContract%s"
+
+  rules = {
+    attestations = {
+      id_token_hints = [
+        {
+          required = true
+          mapping = [
+            {
+              input_claim  = "certNumber"
+              output_claim = "certNumber"
+              indexed      = true
+              required     = true
+            },
+          ]
+        },
+      ]
+      self_issued = {
+        mapping = [
+          {
+            input_claim  = "displayName"
+            output_claim = "displayName"
+          },
+        ]
+      }
+    }
+    validity_interval = 2592000
+    vc = {
+      type = ["DemoContract%s"]
+    }
+  }
+
+  displays = {
+    "en-US" = {
+      card = {
+        title            = "%s"
+        issued_by        = "Demo Issuer"
+        background_color = "#BDD0A7"
+        text_color       = "#000000"
+      }
+      consent = {
+        title = "Share Demo Credentials"
+      }
+      claims = [
+        {
+          claim = "vc.credentialSubject.certNumber"
+          label = "Certificate Number"
+        },
+      ]
+    }
+  }
+}
+`, r.template(data), data.RandomString, data.RandomString, title)
+}
+
+func (r VerifiedIDContractTestResource) multipleIndexedClaims() string {
+	return `
+resource "verifiedid_contract" "test" {
+  authority_id = "00000000-0000-0000-0000-000000000000"
+  name         = "DemoContract"
+
+  rules = {
+    attestations = {
+      id_token_hints = [
+        {
+          mapping = [
+            {
+              input_claim  = "certNumber"
+              output_claim = "certNumber"
+              indexed      = true
+            },
+            {
+              input_claim  = "displayName"
+              output_claim = "displayName"
+              indexed      = true
+            },
+          ]
+        },
+      ]
+    }
+    validity_interval = 2592000
+    vc = {
+      type = ["DemoContract"]
+    }
+  }
+
+  displays = {
+    "en-US" = {
+      card = {
+        title            = "Demo Title"
+        issued_by        = "Demo Issuer"
+        background_color = "#BDD0A7"
+        text_color       = "#000000"
+      }
+      consent = {
+        title = "Share Demo Credentials"
+      }
+    }
+  }
+}
+`
+}
+
+func (r VerifiedIDContractTestResource) unknownDisplayClaim() string {
+	return `
+resource "verifiedid_contract" "test" {
+  authority_id = "00000000-0000-0000-0000-000000000000"
+  name         = "DemoContract"
+
+  rules = {
+    attestations = {
+      self_issued = {
+        mapping = [
+          {
+            input_claim  = "displayName"
+            output_claim = "displayName"
+          },
+        ]
+      }
+    }
+    validity_interval = 2592000
+    vc = {
+      type = ["DemoContract"]
+    }
+  }
+
+  displays = {
+    "en-US" = {
+      card = {
+        title            = "Demo Title"
+        issued_by        = "Demo Issuer"
+        background_color = "#BDD0A7"
+        text_color       = "#000000"
+      }
+      consent = {
+        title = "Share Demo Credentials"
+      }
+      claims = [
+        {
+          claim = "vc.credentialSubject.certNumber"
+          label = "Certificate Number"
+        },
+      ]
+    }
+  }
+}
+`
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VerifiedIDContractsDataSource{}
+
+func NewVerifiedIDContractsDataSource() datasource.DataSource {
+	return &VerifiedIDContractsDataSource{}
+}
+
+// VerifiedIDContractsDataSource defines the data source implementation.
+type VerifiedIDContractsDataSource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDContractsDataSourceModel describes the data source data model.
+type VerifiedIDContractsDataSourceModel struct {
+	Id              types.String                    `tfsdk:"id"`
+	AuthorityId     types.String                    `tfsdk:"authority_id"`
+	Contracts       []contractSummaryModel          `tfsdk:"contracts"`
+	ContractsByName map[string]contractSummaryModel `tfsdk:"contracts_by_name"`
+	Retry           retry.Value                     `tfsdk:"retry"`
+	Timeouts        timeouts.Value                  `tfsdk:"timeouts"`
+}
+
+type contractSummaryModel struct {
+	Id              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Status          types.String `tfsdk:"status"`
+	ManifestUrl     types.String `tfsdk:"manifest_url"`
+	CredentialTypes []string     `tfsdk:"credential_types"`
+}
+
+func (r *VerifiedIDContractsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contracts"
+}
+
+func (r *VerifiedIDContractsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	contractAttributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The ID of the contract.",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The name of the contract.",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "The status of the contract, for example `Enabled`.",
+			Computed:            true,
+		},
+		"manifest_url": schema.StringAttribute{
+			MarkdownDescription: "The URL of the manifest of the contract, used when issuing credentials.",
+			Computed:            true,
+		},
+		"credential_types": schema.ListAttribute{
+			MarkdownDescription: "The types of the verifiable credential issued by the contract.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source lists the Microsoft Entra Verified ID contracts of an authority.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the contracts collection.",
+				Computed:            true,
+			},
+
+			"authority_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority whose contracts are listed.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"contracts": schema.ListNestedAttribute{
+				MarkdownDescription: "The contracts of the authority.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: contractAttributes,
+				},
+			},
+
+			"contracts_by_name": schema.MapNestedAttribute{
+				MarkdownDescription: "The contracts of the authority keyed by name, with one entry per name. If several contracts share a name, only the last one returned by the API is kept and a warning is raised, `contracts` lists all of them.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: contractAttributes,
+				},
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDContractsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDContractsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model VerifiedIDContractsDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	url := contractsUrl(model.AuthorityId.ValueString())
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	responseBody, err := r.client.List(ctx, url, verifiedIDApiVersion, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to list contracts", err)
+		return
+	}
+
+	var contracts contractListApiModel
+	if err := decodeResponseBody(responseBody, &contracts); err != nil {
+		resp.Diagnostics.AddError("Failed to decode contracts", err.Error())
+		return
+	}
+
+	model.Id = types.StringValue(url)
+	model.Contracts = make([]contractSummaryModel, 0, len(contracts.Value))
+	model.ContractsByName = make(map[string]contractSummaryModel, len(contracts.Value))
+	for _, v := range contracts.Value {
+		contract := contractSummaryModel{
+			Id:              types.StringValue(v.Id),
+			Name:            types.StringValue(v.Name),
+			Status:          types.StringValue(v.Status),
+			ManifestUrl:     types.StringValue(v.ManifestUrl),
+			CredentialTypes: []string{},
+		}
+		if v.Rules != nil && v.Rules.Vc != nil {
+			contract.CredentialTypes = stringsOrEmpty(v.Rules.Vc.Type)
+		}
+		model.Contracts = append(model.Contracts, contract)
+		if _, ok := model.ContractsByName[v.Name]; ok {
+			resp.Diagnostics.AddWarning(
+				"Duplicated contract name",
+				fmt.Sprintf("Several contracts are named %q, `contracts_by_name` only keeps the last one returned by the API. Use `contracts` to choose between them.", v.Name),
+			)
+		}
+		model.ContractsByName[v.Name] = contract
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
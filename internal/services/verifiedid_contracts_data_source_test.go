@@ -0,0 +1,41 @@
+package services_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+)
+
+type VerifiedIDContractsTestDataSource struct{}
+
+func TestAcc_ContractsDataSourceBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.verifiedid_contracts", "test")
+	r := VerifiedIDContractsTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("contracts.#").HasValue("1"),
+				check.That(data.ResourceName).Key(fmt.Sprintf("contracts_by_name.DemoContract%s.manifest_url", data.RandomString)).MatchesRegex(regexp.MustCompile(`^https://`)),
+				check.That(data.ResourceName).Key(fmt.Sprintf("contracts_by_name.DemoContract%s.credential_types.0", data.RandomString)).HasValue(fmt.Sprintf("DemoContract%s", data.RandomString)),
+			),
+		},
+	})
+}
+
+func (r VerifiedIDContractsTestDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "verifiedid_contracts" "test" {
+  authority_id = verifiedid_authority.test.id
+
+  depends_on = [verifiedid_contract.test]
+}
+`, VerifiedIDContractTestResource{}.basic(data, "Demo Title"))
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VerifiedIDCredentialRevocationResource{}
+
+func NewVerifiedIDCredentialRevocationResource() resource.Resource {
+	return &VerifiedIDCredentialRevocationResource{}
+}
+
+// VerifiedIDCredentialRevocationResource defines the resource implementation.
+type VerifiedIDCredentialRevocationResource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDCredentialRevocationResourceModel describes the resource data model.
+type VerifiedIDCredentialRevocationResourceModel struct {
+	Id                   types.String   `tfsdk:"id"`
+	AuthorityId          types.String   `tfsdk:"authority_id"`
+	ContractId           types.String   `tfsdk:"contract_id"`
+	IndexedClaimValue    types.String   `tfsdk:"indexed_claim_value"`
+	IndexClaimHash       types.String   `tfsdk:"index_claim_hash"`
+	RevokedCredentialIds types.List     `tfsdk:"revoked_credential_ids"`
+	Retry                retry.Value    `tfsdk:"retry"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VerifiedIDCredentialRevocationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credential_revocation"
+}
+
+func (r *VerifiedIDCredentialRevocationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Revokes the credentials issued by a contract for a value of its indexed claim. " +
+			"The index claim hash, `base64(SHA256(contract_id + indexed_claim_value))`, is computed by the provider. " +
+			"A revocation can't be undone, destroying the resource only removes it from the state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the credentials search used to find the revoked credentials.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"authority_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority of the contract. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"contract_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the contract which issued the credentials. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"indexed_claim_value": schema.StringAttribute{
+				MarkdownDescription: "The value of the indexed claim of the credentials to revoke. Changing this forces a new resource to be created.",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"index_claim_hash": schema.StringAttribute{
+				MarkdownDescription: "The hash of the indexed claim value used to search the credentials.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"revoked_credential_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the credentials revoked by the resource, including the ones which were already revoked.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDCredentialRevocationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDCredentialRevocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *VerifiedIDCredentialRevocationResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	authorityId := model.AuthorityId.ValueString()
+	contractId := model.ContractId.ValueString()
+	hash := utils.IndexClaimHash(contractId, model.IndexedClaimValue.ValueString())
+
+	url := credentialsUrl(authorityId, contractId)
+	options := clients.RequestOptions{
+		QueryParameters: credentialsFilterQueryParameters(hash),
+		RetryOptions:    r.client.RetryOptions(model.Retry, retry.OperationCreate),
+	}
+	var credentials credentialListApiModel
+	responseBody, err := r.client.List(ctx, url, verifiedIDApiVersion, options)
+	switch {
+	case err == nil:
+		if err := decodeResponseBody(responseBody, &credentials); err != nil {
+			resp.Diagnostics.AddError("Failed to decode credentials", err.Error())
+			return
+		}
+	case utils.ResponseErrorWasNotFound(err):
+		// The search responds with 404 when no credentials match the hash.
+	default:
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to search credentials", err)
+		return
+	}
+	if len(credentials.Value) == 0 {
+		resp.Diagnostics.AddError(
+			"Failed to revoke credentials",
+			fmt.Sprintf("No credentials of contract %q were found for the indexed claim value, index claim hash: %q.", contractId, hash),
+		)
+		return
+	}
+
+	model.Id = types.StringValue(url)
+	model.IndexClaimHash = types.StringValue(hash)
+
+	revokedCredentialIds := make([]string, 0, len(credentials.Value))
+	options = clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationCreate),
+	}
+	for _, credential := range credentials.Value {
+		if strings.EqualFold(credential.Status, credentialStatusRevoked) {
+			tflog.Info(ctx, fmt.Sprintf("Credential %q of contract %q is already revoked", credential.Id, contractId))
+			revokedCredentialIds = append(revokedCredentialIds, credential.Id)
+			continue
+		}
+		if _, err := r.client.Action(ctx, http.MethodPost, revokeCredentialUrl(authorityId, contractId, credential.Id), verifiedIDApiVersion, nil, options); err != nil {
+			addResponseErrorDiagnosticWithContext(
+				&resp.Diagnostics,
+				"Failed to revoke credential",
+				fmt.Sprintf("Failed to revoke credential %q, the credentials %v were already revoked.", credential.Id, revokedCredentialIds),
+				err,
+			)
+			// The state records the credentials revoked so far, the resource is tainted and the next apply only
+			// revokes the remaining ones.
+			resp.Diagnostics.Append(r.setRevokedCredentialIds(ctx, model, revokedCredentialIds)...)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Revoked credential %q of contract %q", credential.Id, contractId))
+		revokedCredentialIds = append(revokedCredentialIds, credential.Id)
+	}
+
+	resp.Diagnostics.Append(r.setRevokedCredentialIds(ctx, model, revokedCredentialIds)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDCredentialRevocationResource) setRevokedCredentialIds(ctx context.Context, model *VerifiedIDCredentialRevocationResourceModel, revokedCredentialIds []string) diag.Diagnostics {
+	revokedCredentialIdsValue, diags := types.ListValueFrom(ctx, types.StringType, revokedCredentialIds)
+	model.RevokedCredentialIds = revokedCredentialIdsValue
+	return diags
+}
+
+func (r *VerifiedIDCredentialRevocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A revocation can't be undone, so the state is kept as it is.
+	var model *VerifiedIDCredentialRevocationResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDCredentialRevocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model, state *VerifiedIDCredentialRevocationResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the retry and timeouts settings can be changed in place, the credentials are not revoked again.
+	model.RevokedCredentialIds = state.RevokedCredentialIds
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDCredentialRevocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A revocation can't be undone, the resource is only removed from the state.
+}
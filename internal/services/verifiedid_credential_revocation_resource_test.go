@@ -0,0 +1,206 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+)
+
+type VerifiedIDCredentialRevocationTestResource struct{}
+
+// TestAcc_CredentialRevocationBasic requires a credential issued for the given indexed claim value, which is revoked by the test.
+func TestAcc_CredentialRevocationBasic(t *testing.T) {
+	authorityId := os.Getenv("ARM_TEST_AUTHORITY_ID")
+	contractId := os.Getenv("ARM_TEST_CONTRACT_ID")
+	claimValue := os.Getenv("ARM_TEST_INDEXED_CLAIM_VALUE")
+	if authorityId == "" || contractId == "" || claimValue == "" {
+		t.Skip("ARM_TEST_AUTHORITY_ID, ARM_TEST_CONTRACT_ID and ARM_TEST_INDEXED_CLAIM_VALUE must be set to run this test")
+	}
+
+	data := acceptance.BuildTestData(t, "verifiedid_credential_revocation", "test")
+
+	r := VerifiedIDCredentialRevocationTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(authorityId, contractId, claimValue),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("index_claim_hash").Exists(),
+				check.That(data.ResourceName).Key("revoked_credential_ids.#").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_CredentialRevocationFakeServer(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_credential_revocation", "test")
+
+	r := VerifiedIDCredentialRevocationTestResource{}
+	claimValue := fmt.Sprintf("alice-%s@contoso.com", data.RandomString)
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: VerifiedIDContractTestResource{}.basic(data, "Demo Title"),
+			Check: func(s *terraform.State) error {
+				contract := s.RootModule().Resources["verifiedid_contract.test"].Primary
+				_, err := data.FakeServer.IssueCredential(contract.Attributes["authority_id"], contract.ID, claimValue)
+				return err
+			},
+		},
+		{
+			Config: r.fakeServer(data, claimValue),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("index_claim_hash").Exists(),
+				check.That(data.ResourceName).Key("revoked_credential_ids.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAcc_CredentialRevocationFakeServerMultiplePages(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_credential_revocation", "test")
+	data.FakeServer.SetCredentialsPageSize(1)
+
+	r := VerifiedIDCredentialRevocationTestResource{}
+	claimValue := fmt.Sprintf("alice-%s@contoso.com", data.RandomString)
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: VerifiedIDContractTestResource{}.basic(data, "Demo Title"),
+			Check: func(s *terraform.State) error {
+				contract := s.RootModule().Resources["verifiedid_contract.test"].Primary
+				for i := 0; i < 3; i++ {
+					if _, err := data.FakeServer.IssueCredential(contract.Attributes["authority_id"], contract.ID, claimValue); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Config: r.fakeServer(data, claimValue),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("revoked_credential_ids.#").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAcc_CredentialRevocationFakeServerNotFound(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_credential_revocation", "test")
+
+	r := VerifiedIDCredentialRevocationTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.notFound(data),
+			ExpectError: regexp.MustCompile(`No credentials of contract`),
+		},
+	})
+}
+
+func TestAcc_CredentialRevocationFakeServerPartialFailure(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_credential_revocation", "test")
+
+	r := VerifiedIDCredentialRevocationTestResource{}
+	claimValue := fmt.Sprintf("alice-%s@contoso.com", data.RandomString)
+	var credentialsUrl, firstId, secondId string
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: VerifiedIDContractTestResource{}.basic(data, "Demo Title"),
+			Check: func(s *terraform.State) error {
+				contract := s.RootModule().Resources["verifiedid_contract.test"].Primary
+				authorityId := contract.Attributes["authority_id"]
+				var err error
+				if firstId, err = data.FakeServer.IssueCredential(authorityId, contract.ID, claimValue); err != nil {
+					return err
+				}
+				if secondId, err = data.FakeServer.IssueCredential(authorityId, contract.ID, claimValue); err != nil {
+					return err
+				}
+				credentialsUrl = fmt.Sprintf("authorities/%s/contracts/%s/credentials", authorityId, contract.ID)
+				return nil
+			},
+		},
+		{
+			PreConfig: func() {
+				data.FakeServer.InjectFault(acceptance.Fault{Method: http.MethodPost, Path: fmt.Sprintf("%s/%s/revoke", credentialsUrl, secondId), StatusCode: http.StatusBadRequest, Count: 1})
+			},
+			Config:      r.fakeServer(data, claimValue),
+			ExpectError: regexp.MustCompile(`Failed to revoke credential`),
+		},
+		{
+			// The first credential is already revoked, revoking it again would fail.
+			PreConfig: func() {
+				data.FakeServer.InjectFault(acceptance.Fault{Method: http.MethodPost, Path: fmt.Sprintf("%s/%s/revoke", credentialsUrl, firstId), StatusCode: http.StatusConflict})
+			},
+			Config: r.fakeServer(data, claimValue),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("revoked_credential_ids.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAcc_CredentialRevocationNotFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_credential_revocation", "test")
+
+	r := VerifiedIDCredentialRevocationTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.notFound(data),
+			ExpectError: regexp.MustCompile(`No credentials of contract`),
+		},
+	})
+}
+
+func (r VerifiedIDCredentialRevocationTestResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	// A revocation can't be undone, it exists as long as it is in the state.
+	b := true
+	return &b, nil
+}
+
+func (r VerifiedIDCredentialRevocationTestResource) basic(authorityId, contractId, claimValue string) string {
+	return fmt.Sprintf(`
+resource "verifiedid_credential_revocation" "test" {
+  authority_id        = "%s"
+  contract_id         = "%s"
+  indexed_claim_value = "%s"
+}
+`, authorityId, contractId, claimValue)
+}
+
+func (r VerifiedIDCredentialRevocationTestResource) fakeServer(data acceptance.TestData, claimValue string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "verifiedid_credential_revocation" "test" {
+  authority_id        = verifiedid_contract.test.authority_id
+  contract_id         = verifiedid_contract.test.id
+  indexed_claim_value = "%s"
+}
+`, VerifiedIDContractTestResource{}.basic(data, "Demo Title"), claimValue)
+}
+
+func (r VerifiedIDCredentialRevocationTestResource) notFound(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "verifiedid_credential_revocation" "test" {
+  authority_id        = verifiedid_contract.test.authority_id
+  contract_id         = verifiedid_contract.test.id
+  indexed_claim_value = "unknown-%s"
+}
+`, VerifiedIDContractTestResource{}.basic(data, "Demo Title"), data.RandomString)
+}
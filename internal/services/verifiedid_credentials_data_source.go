@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VerifiedIDCredentialsDataSource{}
+
+func NewVerifiedIDCredentialsDataSource() datasource.DataSource {
+	return &VerifiedIDCredentialsDataSource{}
+}
+
+// VerifiedIDCredentialsDataSource defines the data source implementation.
+type VerifiedIDCredentialsDataSource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDCredentialsDataSourceModel describes the data source data model.
+type VerifiedIDCredentialsDataSourceModel struct {
+	Id                types.String      `tfsdk:"id"`
+	AuthorityId       types.String      `tfsdk:"authority_id"`
+	ContractId        types.String      `tfsdk:"contract_id"`
+	IndexedClaimValue types.String      `tfsdk:"indexed_claim_value"`
+	IndexClaimHash    types.String      `tfsdk:"index_claim_hash"`
+	Credentials       []credentialModel `tfsdk:"credentials"`
+	Retry             retry.Value       `tfsdk:"retry"`
+	Timeouts          timeouts.Value    `tfsdk:"timeouts"`
+}
+
+type credentialModel struct {
+	Id        types.String `tfsdk:"id"`
+	Status    types.String `tfsdk:"status"`
+	IssuedAt  types.String `tfsdk:"issued_at"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+func (r *VerifiedIDCredentialsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credentials"
+}
+
+func (r *VerifiedIDCredentialsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source searches the credentials issued by a contract for a value of its indexed claim, without revoking them. " +
+			"The index claim hash, `base64(SHA256(contract_id + indexed_claim_value))`, is computed by the provider. No matching credentials is not an error.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the credentials search.",
+				Computed:            true,
+			},
+
+			"authority_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority of the contract.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"contract_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the contract which issued the credentials.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"indexed_claim_value": schema.StringAttribute{
+				MarkdownDescription: "The value of the indexed claim of the credentials.",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"index_claim_hash": schema.StringAttribute{
+				MarkdownDescription: "The hash of the indexed claim value used to search the credentials.",
+				Computed:            true,
+			},
+
+			"credentials": schema.ListNestedAttribute{
+				MarkdownDescription: "The credentials issued for the indexed claim value.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the credential.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the credential, for example `valid` or `revoked`.",
+							Computed:            true,
+						},
+						"issued_at": schema.StringAttribute{
+							MarkdownDescription: "The time the credential was issued.",
+							Computed:            true,
+						},
+						"expires_at": schema.StringAttribute{
+							MarkdownDescription: "The time the credential expires.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDCredentialsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDCredentialsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model VerifiedIDCredentialsDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	contractId := model.ContractId.ValueString()
+	hash := utils.IndexClaimHash(contractId, model.IndexedClaimValue.ValueString())
+
+	url := credentialsUrl(model.AuthorityId.ValueString(), contractId)
+	options := clients.RequestOptions{
+		QueryParameters: credentialsFilterQueryParameters(hash),
+		RetryOptions:    r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+
+	var credentials credentialListApiModel
+	responseBody, err := r.client.List(ctx, url, verifiedIDApiVersion, options)
+	switch {
+	case err == nil:
+		if err := decodeResponseBody(responseBody, &credentials); err != nil {
+			resp.Diagnostics.AddError("Failed to decode credentials", err.Error())
+			return
+		}
+	case utils.ResponseErrorWasNotFound(err):
+		// The search responds with 404 when no credentials match the hash.
+		tflog.Info(ctx, fmt.Sprintf("No credentials of contract %q were found for index claim hash %q", contractId, hash))
+	default:
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to search credentials", err)
+		return
+	}
+
+	model.Id = types.StringValue(url)
+	model.IndexClaimHash = types.StringValue(hash)
+	model.Credentials = make([]credentialModel, 0, len(credentials.Value))
+	for _, v := range credentials.Value {
+		model.Credentials = append(model.Credentials, credentialModel{
+			Id:        types.StringValue(v.Id),
+			Status:    types.StringValue(v.Status),
+			IssuedAt:  types.StringValue(v.IssuedAt),
+			ExpiresAt: types.StringValue(v.ExpiresAt),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
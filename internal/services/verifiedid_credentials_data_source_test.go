@@ -0,0 +1,94 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+)
+
+type VerifiedIDCredentialsTestDataSource struct{}
+
+func TestAcc_CredentialsDataSourceEmpty(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.verifiedid_credentials", "test")
+	r := VerifiedIDCredentialsTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.empty(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("index_claim_hash").Exists(),
+				check.That(data.ResourceName).Key("credentials.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAcc_CredentialsDataSourceFakeServerEmpty(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "data.verifiedid_credentials", "test")
+	r := VerifiedIDCredentialsTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.empty(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("credentials.#").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAcc_CredentialsDataSourceFakeServerMultiplePages(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "data.verifiedid_credentials", "test")
+	data.FakeServer.SetCredentialsPageSize(2)
+	r := VerifiedIDCredentialsTestDataSource{}
+	claimValue := fmt.Sprintf("alice-%s@contoso.com", data.RandomString)
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: VerifiedIDContractTestResource{}.basic(data, "Demo Title"),
+			Check: func(s *terraform.State) error {
+				contract := s.RootModule().Resources["verifiedid_contract.test"].Primary
+				for i := 0; i < 3; i++ {
+					if _, err := data.FakeServer.IssueCredential(contract.Attributes["authority_id"], contract.ID, claimValue); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Config: r.claimValue(data, claimValue),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("credentials.#").HasValue("3"),
+			),
+		},
+	})
+}
+
+func (r VerifiedIDCredentialsTestDataSource) claimValue(data acceptance.TestData, claimValue string) string {
+	return fmt.Sprintf(`
+%s
+
+data "verifiedid_credentials" "test" {
+  authority_id        = verifiedid_contract.test.authority_id
+  contract_id         = verifiedid_contract.test.id
+  indexed_claim_value = "%s"
+}
+`, VerifiedIDContractTestResource{}.basic(data, "Demo Title"), claimValue)
+}
+
+func (r VerifiedIDCredentialsTestDataSource) empty(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "verifiedid_credentials" "test" {
+  authority_id        = verifiedid_contract.test.authority_id
+  contract_id         = verifiedid_contract.test.id
+  indexed_claim_value = "unknown-%s"
+}
+`, VerifiedIDContractTestResource{}.basic(data, "Demo Title"), data.RandomString)
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VerifiedIDDidConfigurationDataSource{}
+
+func NewVerifiedIDDidConfigurationDataSource() datasource.DataSource {
+	return &VerifiedIDDidConfigurationDataSource{}
+}
+
+// VerifiedIDDidConfigurationDataSource defines the data source implementation.
+type VerifiedIDDidConfigurationDataSource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDDidConfigurationDataSourceModel describes the data source data model.
+type VerifiedIDDidConfigurationDataSourceModel struct {
+	Id              types.String   `tfsdk:"id"`
+	AuthorityId     types.String   `tfsdk:"authority_id"`
+	DomainUrl       types.String   `tfsdk:"domain_url"`
+	Json            types.String   `tfsdk:"json"`
+	LinkedDids      types.List     `tfsdk:"linked_dids"`
+	LinkedDomainJwt types.String   `tfsdk:"linked_domain_jwt"`
+	Retry           retry.Value    `tfsdk:"retry"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VerifiedIDDidConfigurationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_did_configuration"
+}
+
+func (r *VerifiedIDDidConfigurationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source generates the well-known DID configuration of an authority, which must be hosted at `{domain_url}/.well-known/did-configuration.json` to verify the linked domain.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the action used to generate the DID configuration.",
+				Computed:            true,
+			},
+
+			"authority_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"domain_url": schema.StringAttribute{
+				MarkdownDescription: "The linked domain the DID configuration is generated for, for example `https://www.contoso.com/`. It must be one of the linked domains of the authority.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsHttpsURL(),
+				},
+			},
+
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The DID configuration as a JSON string, ready to be written to `did-configuration.json`.",
+				Computed:            true,
+			},
+
+			"linked_dids": schema.ListAttribute{
+				MarkdownDescription: "The domain linkage credentials of the DID configuration, as JWTs.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+
+			"linked_domain_jwt": schema.StringAttribute{
+				MarkdownDescription: "The first domain linkage credential of the DID configuration, as a JWT.",
+				Computed:            true,
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDDidConfigurationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDDidConfigurationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model VerifiedIDDidConfigurationDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	url := generateWellknownDidConfigurationUrl(model.AuthorityId.ValueString())
+	requestBody := map[string]interface{}{
+		"domainUrl": model.DomainUrl.ValueString(),
+	}
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	responseBody, err := r.client.Action(ctx, http.MethodPost, url, verifiedIDApiVersion, requestBody, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to generate DID configuration", err)
+		return
+	}
+
+	data, err := json.Marshal(responseBody)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to marshal DID configuration", err.Error())
+		return
+	}
+
+	var configuration didConfigurationApiModel
+	if err := json.Unmarshal(data, &configuration); err != nil {
+		resp.Diagnostics.AddError("Failed to decode DID configuration", err.Error())
+		return
+	}
+
+	model.Id = types.StringValue(url)
+	model.Json = types.StringValue(string(data))
+	model.LinkedDomainJwt = types.StringValue("")
+	if len(configuration.LinkedDids) != 0 {
+		model.LinkedDomainJwt = types.StringValue(configuration.LinkedDids[0])
+	}
+
+	linkedDids, diags := types.ListValueFrom(ctx, types.StringType, stringsOrEmpty(configuration.LinkedDids))
+	resp.Diagnostics.Append(diags...)
+	model.LinkedDids = linkedDids
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
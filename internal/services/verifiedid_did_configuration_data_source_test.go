@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+)
+
+type VerifiedIDDidConfigurationTestDataSource struct{}
+
+func TestAcc_DidConfigurationDataSourceBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.verifiedid_did_configuration", "test")
+	r := VerifiedIDDidConfigurationTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("json").IsJson(),
+				check.That(data.ResourceName).Key("linked_dids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("linked_domain_jwt").MatchesRegex(regexp.MustCompile(`^[\w-]+\.[\w-]+\.[\w-]+$`)),
+			),
+		},
+	})
+}
+
+func (r VerifiedIDDidConfigurationTestDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "test" {
+  name               = "Demo Authority %s"
+  linked_domain_urls = ["https://%s.contoso.com/"]
+}
+
+data "verifiedid_did_configuration" "test" {
+  authority_id = verifiedid_authority.test.id
+  domain_url   = "https://%s.contoso.com/"
+}
+`, data.RandomString, data.RandomString, data.RandomString)
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VerifiedIDDidDocumentDataSource{}
+
+func NewVerifiedIDDidDocumentDataSource() datasource.DataSource {
+	return &VerifiedIDDidDocumentDataSource{}
+}
+
+// VerifiedIDDidDocumentDataSource defines the data source implementation.
+type VerifiedIDDidDocumentDataSource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDDidDocumentDataSourceModel describes the data source data model.
+type VerifiedIDDidDocumentDataSourceModel struct {
+	Id                    types.String   `tfsdk:"id"`
+	AuthorityId           types.String   `tfsdk:"authority_id"`
+	DomainUrl             types.String   `tfsdk:"domain_url"`
+	Json                  types.String   `tfsdk:"json"`
+	Did                   types.String   `tfsdk:"did"`
+	VerificationMethodIds types.List     `tfsdk:"verification_method_ids"`
+	LinkedDomains         types.List     `tfsdk:"linked_domains"`
+	Retry                 retry.Value    `tfsdk:"retry"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VerifiedIDDidDocumentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_did_document"
+}
+
+func (r *VerifiedIDDidDocumentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source generates the DID document (`did.json`) of a `did:web` authority, which must be hosted at `{domain_url}/.well-known/did.json`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the action used to generate the DID document.",
+				Computed:            true,
+			},
+
+			"authority_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"domain_url": schema.StringAttribute{
+				MarkdownDescription: "The linked domain the DID document is generated for, for example `https://www.contoso.com/`.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsHttpsURL(),
+				},
+			},
+
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The DID document as a JSON string, ready to be written to `did.json`.",
+				Computed:            true,
+			},
+
+			"did": schema.StringAttribute{
+				MarkdownDescription: "The decentralized identifier (DID) described by the document.",
+				Computed:            true,
+			},
+
+			"verification_method_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the verification methods (public keys) of the DID document.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+
+			"linked_domains": schema.ListAttribute{
+				MarkdownDescription: "The origins of the `LinkedDomains` service of the DID document.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDDidDocumentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDDidDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model VerifiedIDDidDocumentDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	url := generateDidDocumentUrl(model.AuthorityId.ValueString())
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	data, document, err := generateDidDocument(ctx, r.client, model.AuthorityId.ValueString(), model.DomainUrl.ValueString(), options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to generate DID document", err)
+		return
+	}
+
+	model.Id = types.StringValue(url)
+	model.Json = types.StringValue(data)
+	model.Did = types.StringValue(document.Id)
+
+	verificationMethodIdsValue, diags := types.ListValueFrom(ctx, types.StringType, document.verificationMethodIds())
+	resp.Diagnostics.Append(diags...)
+	model.VerificationMethodIds = verificationMethodIdsValue
+
+	linkedDomainsValue, diags := types.ListValueFrom(ctx, types.StringType, document.linkedDomains())
+	resp.Diagnostics.Append(diags...)
+	model.LinkedDomains = linkedDomainsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// generateDidDocument generates the DID document of the authority and returns it both as a JSON string and decoded.
+func generateDidDocument(ctx context.Context, client *clients.VerifiedIDClient, authorityId, domainUrl string, options clients.RequestOptions) (string, *didDocumentApiModel, error) {
+	requestBody := map[string]interface{}{
+		"domainUrl": domainUrl,
+	}
+	responseBody, err := client.Action(ctx, http.MethodPost, generateDidDocumentUrl(authorityId), verifiedIDApiVersion, requestBody, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data, err := json.Marshal(responseBody)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var document didDocumentApiModel
+	if err := json.Unmarshal(data, &document); err != nil {
+		return "", nil, err
+	}
+	return string(data), &document, nil
+}
+
+func (document didDocumentApiModel) verificationMethodIds() []string {
+	result := make([]string, 0, len(document.VerificationMethod))
+	for _, v := range document.VerificationMethod {
+		result = append(result, v.Id)
+	}
+	return result
+}
+
+// linkedDomains returns the origins of the LinkedDomains service of the DID document.
+func (document didDocumentApiModel) linkedDomains() []string {
+	result := make([]string, 0)
+	for _, v := range document.Service {
+		if v.Type != "LinkedDomains" {
+			continue
+		}
+		switch endpoint := v.ServiceEndpoint.(type) {
+		case string:
+			result = append(result, endpoint)
+		case map[string]interface{}:
+			if origins, ok := endpoint["origins"].([]interface{}); ok {
+				for _, origin := range origins {
+					if s, ok := origin.(string); ok {
+						result = append(result, s)
+					}
+				}
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package services_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+)
+
+type VerifiedIDDidDocumentTestDataSource struct{}
+
+func TestAcc_DidDocumentDataSourceBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.verifiedid_did_document", "test")
+	r := VerifiedIDDidDocumentTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("json").IsJson(),
+				check.That(data.ResourceName).Key("did").MatchesRegex(regexp.MustCompile(`^did:web:`)),
+				check.That(data.ResourceName).Key("verification_method_ids.#").Exists(),
+				check.That(data.ResourceName).Key("did").MatchesOtherKey(check.That("verifiedid_authority.test").Key("did")),
+			),
+		},
+	})
+}
+
+func (r VerifiedIDDidDocumentTestDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "test" {
+  name               = "Demo Authority %s"
+  linked_domain_urls = ["https://%s.contoso.com/"]
+}
+
+data "verifiedid_did_document" "test" {
+  authority_id = verifiedid_authority.test.id
+  domain_url   = "https://%s.contoso.com/"
+}
+`, data.RandomString, data.RandomString, data.RandomString)
+}
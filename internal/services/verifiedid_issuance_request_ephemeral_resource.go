@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &VerifiedIDIssuanceRequestEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &VerifiedIDIssuanceRequestEphemeralResource{}
+)
+
+// requestServiceApiVersion is the version of the Request Service API.
+const requestServiceApiVersion = "v1.0"
+
+// issuanceRequestTimeout bounds the creation of an issuance request, ephemeral resources have no timeouts block.
+const issuanceRequestTimeout = 5 * time.Minute
+
+func createIssuanceRequestUrl() string {
+	return "verifiableCredentials/createIssuanceRequest"
+}
+
+func NewVerifiedIDIssuanceRequestEphemeralResource() ephemeral.EphemeralResource {
+	return &VerifiedIDIssuanceRequestEphemeralResource{}
+}
+
+// VerifiedIDIssuanceRequestEphemeralResource defines the ephemeral resource implementation.
+type VerifiedIDIssuanceRequestEphemeralResource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDIssuanceRequestEphemeralResourceModel describes the ephemeral resource data model.
+type VerifiedIDIssuanceRequestEphemeralResourceModel struct {
+	Authority      types.String `tfsdk:"authority"`
+	ManifestUrl    types.String `tfsdk:"manifest_url"`
+	CredentialType types.String `tfsdk:"credential_type"`
+	ClientName     types.String `tfsdk:"client_name"`
+	Claims         types.Map    `tfsdk:"claims"`
+	Pin            types.String `tfsdk:"pin"`
+	Callback       types.Object `tfsdk:"callback"`
+	IncludeQRCode  types.Bool   `tfsdk:"include_qr_code"`
+	Retry          retry.Value  `tfsdk:"retry"`
+	RequestId      types.String `tfsdk:"request_id"`
+	RequestUrl     types.String `tfsdk:"request_url"`
+	Expiry         types.String `tfsdk:"expiry"`
+	QRCode         types.String `tfsdk:"qr_code"`
+}
+
+type issuanceRequestCallbackModel struct {
+	Url     types.String `tfsdk:"url"`
+	State   types.String `tfsdk:"state"`
+	Headers types.Map    `tfsdk:"headers"`
+}
+
+// issuanceRequestApiModel is the Request Service API representation of an issuance request.
+type issuanceRequestApiModel struct {
+	Authority     string                           `json:"authority"`
+	IncludeQRCode bool                             `json:"includeQRCode,omitempty"`
+	Callback      issuanceRequestCallbackApiModel  `json:"callback"`
+	Registration  issuanceRequestRegistrationModel `json:"registration"`
+	Type          string                           `json:"type"`
+	Manifest      string                           `json:"manifest"`
+	Claims        map[string]string                `json:"claims,omitempty"`
+	Pin           *issuanceRequestPinApiModel      `json:"pin,omitempty"`
+}
+
+type issuanceRequestCallbackApiModel struct {
+	Url     string            `json:"url"`
+	State   string            `json:"state"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type issuanceRequestRegistrationModel struct {
+	ClientName string `json:"clientName"`
+}
+
+type issuanceRequestPinApiModel struct {
+	Value  string `json:"value"`
+	Length int    `json:"length"`
+}
+
+// issuanceResponseApiModel is the response of the Request Service API to the creation of an issuance request, its
+// expiry is a Unix timestamp in seconds.
+type issuanceResponseApiModel struct {
+	RequestId string `json:"requestId"`
+	Url       string `json:"url"`
+	Expiry    int64  `json:"expiry"`
+	QRCode    string `json:"qrCode"`
+}
+
+func (r *VerifiedIDIssuanceRequestEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_issuance_request"
+}
+
+func (r *VerifiedIDIssuanceRequestEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This ephemeral resource creates an issuance request with the Request Service API, for example to prove a contract issues credentials or to render the QR code of the request. The request isn't persisted to the plan or the state, so the claims, the PIN and the callback headers aren't either.",
+
+		Attributes: map[string]schema.Attribute{
+			"authority": schema.StringAttribute{
+				MarkdownDescription: "The decentralized identifier (DID) of the authority issuing the credential, for example the `did` of a `verifiedid_authority`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"manifest_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the manifest of the contract, for example the `manifest_url` of a `verifiedid_contract`.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsURL(),
+				},
+			},
+
+			"credential_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the credential, it must match one of the `vc.type` of the rules of the contract.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"client_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the issuer shown in the wallet.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"claims": schema.MapAttribute{
+				MarkdownDescription: "The claims of the credential provided by the issuer, required by the `id_token_hints` attestations of the contract.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+			},
+
+			"pin": schema.StringAttribute{
+				MarkdownDescription: "The PIN the holder must enter in the wallet to complete the issuance, from 4 to 16 digits. It can only be used with claims.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9]{4,16}$`), "must be from 4 to 16 digits"),
+					stringvalidator.AlsoRequires(path.MatchRoot("claims")),
+				},
+			},
+
+			"callback": schema.SingleNestedAttribute{
+				MarkdownDescription: "The callback the Request Service calls with the progress of the request.",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the callback.",
+						Required:            true,
+						Validators: []validator.String{
+							myvalidator.StringIsURL(),
+						},
+					},
+					"state": schema.StringAttribute{
+						MarkdownDescription: "A value passed back to the callback, to correlate the events with the request.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"headers": schema.MapAttribute{
+						MarkdownDescription: "The headers sent to the callback, for example an API key.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+
+			"include_qr_code": schema.BoolAttribute{
+				MarkdownDescription: "Whether the response includes the QR code of the request. Defaults to `false`.",
+				Optional:            true,
+			},
+
+			"retry": retry.Schema(ctx),
+
+			"request_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the request, passed to the callback.",
+				Computed:            true,
+			},
+
+			"request_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the request, which the wallet opens, for example from a QR code.",
+				Computed:            true,
+			},
+
+			"expiry": schema.StringAttribute{
+				MarkdownDescription: "When the request expires, in RFC 3339 format.",
+				Computed:            true,
+			},
+
+			"qr_code": schema.StringAttribute{
+				MarkdownDescription: "The QR code of the request as a data URL of a PNG image, when `include_qr_code` is `true`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *VerifiedIDIssuanceRequestEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.RequestServiceClient
+	}
+}
+
+func (r *VerifiedIDIssuanceRequestEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var model VerifiedIDIssuanceRequestEphemeralResourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, issuanceRequestTimeout)
+	defer cancel()
+
+	var callback issuanceRequestCallbackModel
+	if resp.Diagnostics.Append(model.Callback.As(ctx, &callback, basetypes.ObjectAsOptions{})...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestBody := issuanceRequestApiModel{
+		Authority:     model.Authority.ValueString(),
+		IncludeQRCode: model.IncludeQRCode.ValueBool(),
+		Callback: issuanceRequestCallbackApiModel{
+			Url:     callback.Url.ValueString(),
+			State:   callback.State.ValueString(),
+			Headers: AsMapOfString(callback.Headers),
+		},
+		Registration: issuanceRequestRegistrationModel{
+			ClientName: model.ClientName.ValueString(),
+		},
+		Type:     model.CredentialType.ValueString(),
+		Manifest: model.ManifestUrl.ValueString(),
+		Claims:   AsMapOfString(model.Claims),
+	}
+	if pin := model.Pin.ValueString(); pin != "" {
+		requestBody.Pin = &issuanceRequestPinApiModel{
+			Value:  pin,
+			Length: len(pin),
+		}
+	}
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationCreate),
+	}
+	responseBody, err := r.client.Action(ctx, http.MethodPost, createIssuanceRequestUrl(), requestServiceApiVersion, requestBody, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to create issuance request", err)
+		return
+	}
+
+	data, err := json.Marshal(responseBody)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to marshal response", err.Error())
+		return
+	}
+	var response issuanceResponseApiModel
+	if err := json.Unmarshal(data, &response); err != nil {
+		resp.Diagnostics.AddError("Failed to unmarshal response", err.Error())
+		return
+	}
+
+	model.RequestId = types.StringValue(response.RequestId)
+	model.RequestUrl = types.StringValue(response.Url)
+	model.Expiry = types.StringValue(time.Unix(response.Expiry, 0).UTC().Format(time.RFC3339))
+	model.QRCode = types.StringNull()
+	if response.QRCode != "" {
+		model.QRCode = types.StringValue(response.QRCode)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
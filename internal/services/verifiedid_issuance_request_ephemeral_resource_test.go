@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+)
+
+type VerifiedIDIssuanceRequestEphemeralResource struct{}
+
+func TestAcc_IssuanceRequestFakeServer(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_issuance_request", "test")
+	trafficLogFile := filepath.Join(t.TempDir(), "traffic.har")
+
+	r := VerifiedIDIssuanceRequestEphemeralResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data, trafficLogFile),
+			Check: func(s *terraform.State) error {
+				if _, ok := s.RootModule().Resources["ephemeral.verifiedid_issuance_request.test"]; ok {
+					return fmt.Errorf("expected the issuance request not to be persisted to the state")
+				}
+				requests := data.FakeServer.IssuanceRequests()
+				if len(requests) == 0 {
+					return fmt.Errorf("expected an issuance request to be created")
+				}
+				request := requests[len(requests)-1]
+				if pin, _ := request["pin"].(map[string]interface{}); pin["value"] != "1234" || pin["length"] != float64(4) {
+					return fmt.Errorf("unexpected PIN %v", request["pin"])
+				}
+				if claims, _ := request["claims"].(map[string]interface{}); claims["certNumber"] != "12345" {
+					return fmt.Errorf("unexpected claims %v", request["claims"])
+				}
+				if callback, _ := request["callback"].(map[string]interface{}); callback["headers"] == nil {
+					return fmt.Errorf("expected the callback headers to be sent, got %v", request["callback"])
+				}
+
+				// the provider is served by the test process, the entries written in batches are written now
+				clients.FlushTrafficLogs()
+				trafficLog, err := os.ReadFile(trafficLogFile)
+				if err != nil {
+					return err
+				}
+				if !strings.Contains(string(trafficLog), "createIssuanceRequest") {
+					return fmt.Errorf("expected the issuance request to be written to the traffic log")
+				}
+				if strings.Contains(string(trafficLog), r.callbackApiKey(data)) {
+					return fmt.Errorf("expected the callback headers to be redacted from the traffic log")
+				}
+				return nil
+			},
+		},
+	})
+}
+
+func TestAcc_IssuanceRequestUnknownManifest(t *testing.T) {
+	data := acceptance.BuildTestDataWithFakeServer(t, "verifiedid_issuance_request", "test")
+
+	r := VerifiedIDIssuanceRequestEphemeralResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      r.unknownManifest(data),
+			ExpectError: regexp.MustCompile(`Failed to create issuance request`),
+		},
+	})
+}
+
+func (r VerifiedIDIssuanceRequestEphemeralResource) basic(data acceptance.TestData, trafficLogFile string) string {
+	return fmt.Sprintf(`
+provider "verifiedid" {
+  traffic_log_file = %q
+}
+
+%s
+
+ephemeral "verifiedid_issuance_request" "test" {
+  authority       = verifiedid_authority.test.did
+  manifest_url    = verifiedid_contract.test.manifest_url
+  credential_type = "DemoContract%s"
+  client_name     = "Demo Issuer"
+  include_qr_code = true
+
+  claims = {
+    certNumber = "12345"
+  }
+  pin = "1234"
+
+  callback = {
+    url   = "https://www.contoso.com/api/issuer/callback"
+    state = "acctest-%s"
+    headers = {
+      api-key = "%s"
+    }
+  }
+}
+`, trafficLogFile, VerifiedIDContractTestResource{}.basic(data, "Demo Title"), data.RandomString, data.RandomString, r.callbackApiKey(data))
+}
+
+func (r VerifiedIDIssuanceRequestEphemeralResource) callbackApiKey(data acceptance.TestData) string {
+	return fmt.Sprintf("acctest-api-key-%s", data.RandomString)
+}
+
+func (r VerifiedIDIssuanceRequestEphemeralResource) unknownManifest(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "verifiedid_issuance_request" "test" {
+  authority       = verifiedid_authority.test.did
+  manifest_url    = "https://www.contoso.com/unknown/manifest"
+  credential_type = "DemoContract%s"
+  client_name     = "Demo Issuer"
+
+  callback = {
+    url   = "https://www.contoso.com/api/issuer/callback"
+    state = "acctest-%s"
+  }
+}
+`, VerifiedIDContractTestResource{}.basic(data, "Demo Title"), data.RandomString, data.RandomString)
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/myvalidator"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VerifiedIDLinkedDomainValidationResource{}
+
+// linkedDomainValidationPollInterval is the delay between two reads of the authority while waiting for the
+// linked domain to be verified.
+const linkedDomainValidationPollInterval = 10 * time.Second
+
+func NewVerifiedIDLinkedDomainValidationResource() resource.Resource {
+	return &VerifiedIDLinkedDomainValidationResource{}
+}
+
+// VerifiedIDLinkedDomainValidationResource defines the resource implementation.
+type VerifiedIDLinkedDomainValidationResource struct {
+	client *clients.VerifiedIDClient
+}
+
+// VerifiedIDLinkedDomainValidationResourceModel describes the resource data model.
+type VerifiedIDLinkedDomainValidationResourceModel struct {
+	Id                types.String   `tfsdk:"id"`
+	AuthorityId       types.String   `tfsdk:"authority_id"`
+	DomainUrl         types.String   `tfsdk:"domain_url"`
+	Verified          types.Bool     `tfsdk:"verified"`
+	DidDocumentStatus types.String   `tfsdk:"did_document_status"`
+	Retry             retry.Value    `tfsdk:"retry"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VerifiedIDLinkedDomainValidationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_linked_domain_validation"
+}
+
+func (r *VerifiedIDLinkedDomainValidationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Validates the well-known DID configuration of a linked domain of an authority and waits until the authority reports the domain as verified, or `timeouts.create` expires. " +
+			"The DID configuration must be hosted at `{domain_url}/.well-known/did-configuration.json` before the validation is triggered. " +
+			"If the authority no longer reports the domain as verified, the resource is removed from the state and the validation runs again on the next apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the action used to validate the linked domain.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"authority_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the authority. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"domain_url": schema.StringAttribute{
+				MarkdownDescription: "The linked domain to validate, for example `https://www.contoso.com/`. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsHttpsURL(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authority reports the linked domain as verified.",
+				Computed:            true,
+			},
+
+			"did_document_status": schema.StringAttribute{
+				MarkdownDescription: "The publishing status of the DID document of the authority.",
+				Computed:            true,
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDLinkedDomainValidationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+	}
+}
+
+func (r *VerifiedIDLinkedDomainValidationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *VerifiedIDLinkedDomainValidationResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	url := validateWellKnownDidConfigurationUrl(model.AuthorityId.ValueString())
+	requestBody := map[string]interface{}{
+		"domainUrl": model.DomainUrl.ValueString(),
+	}
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationCreate),
+	}
+	if _, err := r.client.Action(ctx, http.MethodPost, url, verifiedIDApiVersion, requestBody, options); err != nil {
+		addResponseErrorDiagnosticWithContext(
+			&resp.Diagnostics,
+			"Failed to validate linked domain",
+			fmt.Sprintf("The validation of the DID configuration of %q failed. Make sure it is hosted at `.well-known/did-configuration.json` of the domain.", model.DomainUrl.ValueString()),
+			err,
+		)
+		return
+	}
+	model.Id = types.StringValue(url)
+
+	for {
+		// The authority is polled until it changes, so the cached responses are bypassed.
+		didModel, err := readAuthorityDidModel(clients.WithoutReadCache(ctx), r.client, model.AuthorityId.ValueString(), options)
+		if err != nil {
+			addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read authority", err)
+			return
+		}
+		model.Verified = types.BoolValue(didModel.LinkedDomainsVerified)
+		model.DidDocumentStatus = types.StringValue(didModel.DidDocumentStatus)
+		if didModel.LinkedDomainsVerified {
+			break
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Linked domain %q of authority %q is not verified yet, DID document status: %q", model.DomainUrl.ValueString(), model.AuthorityId.ValueString(), didModel.DidDocumentStatus))
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(
+				"Failed to validate linked domain",
+				fmt.Sprintf("The authority %q did not report the linked domain %q as verified before the timeout expired, DID document status: %q.", model.AuthorityId.ValueString(), model.DomainUrl.ValueString(), didModel.DidDocumentStatus),
+			)
+			return
+		case <-time.After(linkedDomainValidationPollInterval):
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDLinkedDomainValidationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model *VerifiedIDLinkedDomainValidationResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	didModel, err := readAuthorityDidModel(ctx, r.client, model.AuthorityId.ValueString(), options)
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Authority %q was not found - removing from state", model.AuthorityId.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read authority", err)
+		return
+	}
+
+	if !didModel.LinkedDomainsVerified {
+		tflog.Info(ctx, fmt.Sprintf("Linked domain %q of authority %q is no longer verified - removing from state", model.DomainUrl.ValueString(), model.AuthorityId.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	model.Verified = types.BoolValue(didModel.LinkedDomainsVerified)
+	model.DidDocumentStatus = types.StringValue(didModel.DidDocumentStatus)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDLinkedDomainValidationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model, state *VerifiedIDLinkedDomainValidationResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the retry and timeouts settings can be changed in place, the validation is not run again.
+	model.Verified = state.Verified
+	model.DidDocumentStatus = state.DidDocumentStatus
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDLinkedDomainValidationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The validation can't be undone, the resource is only removed from the state.
+}
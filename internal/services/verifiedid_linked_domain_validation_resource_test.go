@@ -0,0 +1,86 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+)
+
+type VerifiedIDLinkedDomainValidationTestResource struct{}
+
+// TestAcc_LinkedDomainValidationBasic requires an authority whose DID configuration is already hosted on the linked domain.
+func TestAcc_LinkedDomainValidationBasic(t *testing.T) {
+	authorityId := os.Getenv("ARM_TEST_AUTHORITY_ID")
+	domainUrl := os.Getenv("ARM_TEST_LINKED_DOMAIN_URL")
+	if authorityId == "" || domainUrl == "" {
+		t.Skip("ARM_TEST_AUTHORITY_ID and ARM_TEST_LINKED_DOMAIN_URL must be set to run this test")
+	}
+
+	data := acceptance.BuildTestData(t, "verifiedid_linked_domain_validation", "test")
+
+	r := VerifiedIDLinkedDomainValidationTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(authorityId, domainUrl),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("verified").HasValue("true"),
+				check.That(data.ResourceName).Key("did_document_status").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_LinkedDomainValidationNotHosted(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_linked_domain_validation", "test")
+
+	r := VerifiedIDLinkedDomainValidationTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.notHosted(data),
+			ExpectError: regexp.MustCompile(`Failed to validate linked domain`),
+		},
+	})
+}
+
+func (r VerifiedIDLinkedDomainValidationTestResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	// The validation can't be undone, it exists as long as it is in the state.
+	b := true
+	return &b, nil
+}
+
+func (r VerifiedIDLinkedDomainValidationTestResource) basic(authorityId, domainUrl string) string {
+	return fmt.Sprintf(`
+resource "verifiedid_linked_domain_validation" "test" {
+  authority_id = "%s"
+  domain_url   = "%s"
+}
+`, authorityId, domainUrl)
+}
+
+func (r VerifiedIDLinkedDomainValidationTestResource) notHosted(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "verifiedid_authority" "test" {
+  name               = "Demo Authority %s"
+  linked_domain_urls = ["https://%s.contoso.com/"]
+}
+
+resource "verifiedid_linked_domain_validation" "test" {
+  authority_id = verifiedid_authority.test.id
+  domain_url   = "https://%s.contoso.com/"
+
+  timeouts {
+    create = "1m"
+  }
+}
+`, data.RandomString, data.RandomString, data.RandomString)
+}
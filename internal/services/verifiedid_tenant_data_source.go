@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &VerifiedIDTenantDataSource{}
+
+const (
+	tenantStatusOnboarded    = "Onboarded"
+	tenantStatusNotOnboarded = "NotOnboarded"
+)
+
+func NewVerifiedIDTenantDataSource() datasource.DataSource {
+	return &VerifiedIDTenantDataSource{}
+}
+
+// VerifiedIDTenantDataSource defines the data source implementation.
+type VerifiedIDTenantDataSource struct {
+	client   *clients.VerifiedIDClient
+	tenantId string
+}
+
+// VerifiedIDTenantDataSourceModel describes the data source data model.
+type VerifiedIDTenantDataSourceModel struct {
+	Id        types.String   `tfsdk:"id"`
+	TenantId  types.String   `tfsdk:"tenant_id"`
+	Onboarded types.Bool     `tfsdk:"onboarded"`
+	Status    types.String   `tfsdk:"status"`
+	Retry     retry.Value    `tfsdk:"retry"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VerifiedIDTenantDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant"
+}
+
+func (r *VerifiedIDTenantDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This data source reports whether the tenant the provider is authenticated against is onboarded to Verified ID.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL used to check the onboarding status.",
+				Computed:            true,
+			},
+
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: "The tenant ID configured in the provider, empty when it is determined by the credential.",
+				Computed:            true,
+			},
+
+			"onboarded": schema.BoolAttribute{
+				MarkdownDescription: "Whether the tenant is onboarded to Verified ID.",
+				Computed:            true,
+			},
+
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The onboarding status of the tenant, `Onboarded` or `NotOnboarded`.",
+				Computed:            true,
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *VerifiedIDTenantDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+		if v.Option != nil {
+			r.tenantId = v.Option.TenantId
+		}
+	}
+}
+
+func (r *VerifiedIDTenantDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model VerifiedIDTenantDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	onboarded, err := tenantIsOnboarded(ctx, r.client, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read onboarding status", err)
+		return
+	}
+
+	model.Id = types.StringValue(authoritiesUrl())
+	model.TenantId = types.StringValue(r.tenantId)
+	model.Onboarded = types.BoolValue(onboarded)
+	model.Status = types.StringValue(tenantStatus(onboarded))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func tenantStatus(onboarded bool) string {
+	if onboarded {
+		return tenantStatusOnboarded
+	}
+	return tenantStatusNotOnboarded
+}
@@ -0,0 +1,33 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+)
+
+type VerifiedIDTenantTestDataSource struct{}
+
+func TestAcc_TenantDataSourceBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.verifiedid_tenant", "test")
+	r := VerifiedIDTenantTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("onboarded").HasValue("true"),
+				check.That(data.ResourceName).Key("status").HasValue("Onboarded"),
+			),
+		},
+	})
+}
+
+func (r VerifiedIDTenantTestDataSource) basic() string {
+	return `
+data "verifiedid_tenant" "test" {
+}
+`
+}
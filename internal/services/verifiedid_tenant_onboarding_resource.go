@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/retry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &VerifiedIDTenantOnboardingResource{}
+
+func NewVerifiedIDTenantOnboardingResource() resource.Resource {
+	return &VerifiedIDTenantOnboardingResource{}
+}
+
+// VerifiedIDTenantOnboardingResource defines the resource implementation.
+type VerifiedIDTenantOnboardingResource struct {
+	client   *clients.VerifiedIDClient
+	tenantId string
+}
+
+// VerifiedIDTenantOnboardingResourceModel describes the resource data model.
+type VerifiedIDTenantOnboardingResourceModel struct {
+	Id          types.String   `tfsdk:"id"`
+	TenantId    types.String   `tfsdk:"tenant_id"`
+	AllowOptOut types.Bool     `tfsdk:"allow_opt_out"`
+	Status      types.String   `tfsdk:"status"`
+	Retry       retry.Value    `tfsdk:"retry"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *VerifiedIDTenantOnboardingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_onboarding"
+}
+
+func (r *VerifiedIDTenantOnboardingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Onboards the tenant the provider is authenticated against to Verified ID. " +
+			"Onboarding an already onboarded tenant succeeds, so existing tenants can be managed without an import. " +
+			"Destroying the resource only removes it from the state, unless `allow_opt_out` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the action used to onboard the tenant.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: "The tenant ID configured in the provider, empty when it is determined by the credential.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"allow_opt_out": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the resource opts the tenant out of Verified ID. " +
+					"**Opting out permanently deletes every authority, contract and issued credential of the tenant.** Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The onboarding status of the tenant.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *VerifiedIDTenantOnboardingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.VerifiedIDClient
+		if v.Option != nil {
+			r.tenantId = v.Option.TenantId
+		}
+	}
+}
+
+func (r *VerifiedIDTenantOnboardingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *VerifiedIDTenantOnboardingResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationCreate),
+	}
+	// The onboarding changes the response of the authorities, which don't overlap its path.
+	defer r.client.InvalidateReadCache(authoritiesUrl())
+	if _, err := r.client.Action(ctx, http.MethodPost, onboardUrl(), verifiedIDApiVersion, nil, options); err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to onboard tenant", err)
+		return
+	}
+	tflog.Info(ctx, "Onboarded the tenant to Verified ID")
+
+	model.Id = types.StringValue(onboardUrl())
+	model.TenantId = types.StringValue(r.tenantId)
+	model.Status = types.StringValue(tenantStatusOnboarded)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDTenantOnboardingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model *VerifiedIDTenantOnboardingResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationRead),
+	}
+	onboarded, err := tenantIsOnboarded(ctx, r.client, options)
+	if err != nil {
+		addResponseErrorDiagnostic(&resp.Diagnostics, "Failed to read onboarding status", err)
+		return
+	}
+	if !onboarded {
+		tflog.Info(ctx, "Tenant is no longer onboarded to Verified ID - removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	model.Status = types.StringValue(tenantStatusOnboarded)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDTenantOnboardingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model, state *VerifiedIDTenantOnboardingResourceModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only allow_opt_out, retry and timeouts can be changed in place, the tenant is not onboarded again.
+	model.Status = state.Status
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *VerifiedIDTenantOnboardingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model *VerifiedIDTenantOnboardingResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !model.AllowOptOut.ValueBool() {
+		// Opting out deletes every authority and contract of the tenant, the resource is only removed from the state.
+		tflog.Info(ctx, "allow_opt_out is not set - removing the tenant onboarding from state without opting out")
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	options := clients.RequestOptions{
+		RetryOptions: r.client.RetryOptions(model.Retry, retry.OperationDelete),
+	}
+	// The opt-out deletes every authority, which doesn't overlap its path.
+	defer r.client.InvalidateReadCache(authoritiesUrl())
+	if _, err := r.client.Action(ctx, http.MethodPost, optOutUrl(), verifiedIDApiVersion, nil, options); err != nil {
+		addResponseErrorDiagnosticWithContext(&resp.Diagnostics, "Failed to opt out tenant", "The tenant could not be opted out of Verified ID.", err)
+		return
+	}
+	tflog.Info(ctx, "Opted the tenant out of Verified ID")
+}
@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/acceptance/check"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
+)
+
+type VerifiedIDTenantOnboardingTestResource struct{}
+
+func TestAcc_TenantOnboardingBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "verifiedid_tenant_onboarding", "test")
+
+	r := VerifiedIDTenantOnboardingTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("status").HasValue("Onboarded"),
+				check.That(data.ResourceName).Key("allow_opt_out").HasValue("false"),
+			),
+		},
+	})
+}
+
+func (r VerifiedIDTenantOnboardingTestResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	// The tenant is not opted out on destroy, it stays onboarded after the test.
+	b := true
+	return &b, nil
+}
+
+func (r VerifiedIDTenantOnboardingTestResource) basic() string {
+	return `
+resource "verifiedid_tenant_onboarding" "test" {
+}
+`
+}
@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// IndexClaimHash returns the hash used by the Verified ID Admin API to search credentials by the value of their
+// indexed claim, which is base64(SHA256(contractId + claimValue)).
+func IndexClaimHash(contractId string, claimValue string) string {
+	hash := sha256.Sum256([]byte(contractId + claimValue))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
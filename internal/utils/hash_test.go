@@ -0,0 +1,32 @@
+package utils
+
+import "testing"
+
+func TestIndexClaimHash(t *testing.T) {
+	testcases := []struct {
+		name       string
+		contractId string
+		claimValue string
+		want       string
+	}{
+		{
+			name:       "email claim",
+			contractId: "5d6a4b0b-0000-4000-8000-000000000001",
+			claimValue: "alice@contoso.com",
+			want:       "DIELsQcapPBFjIr/E1R5rjwNC5N9vZVvCI+ZFAfcA04=",
+		},
+		{
+			name:       "empty input",
+			contractId: "",
+			claimValue: "",
+			want:       "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IndexClaimHash(tc.contractId, tc.claimValue); got != tc.want {
+				t.Fatalf("IndexClaimHash(%q, %q) = %q, want %q", tc.contractId, tc.claimValue, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseJSONPointer returns the reference tokens of a JSON Pointer (RFC 6901), such as `/rules/attestations`, with the
+// `~1` and `~0` escapes decoded. The empty pointer refers to the whole document and has no tokens.
+func ParseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("JSON pointer %q must start with a `/`", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, fmt.Errorf("JSON pointer %q has an invalid escape in %q, only `~0` and `~1` are allowed", pointer, token)
+			}
+		}
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
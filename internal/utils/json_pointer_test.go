@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONPointer(t *testing.T) {
+	testcases := []struct {
+		pointer string
+		want    []string
+		wantErr bool
+	}{
+		{pointer: "", want: []string{}},
+		{pointer: "/", want: []string{""}},
+		{pointer: "/rules/attestations/idTokens/0", want: []string{"rules", "attestations", "idTokens", "0"}},
+		{pointer: "/a~1b/m~0n", want: []string{"a/b", "m~n"}},
+		{pointer: "/~01", want: []string{"~1"}},
+		{pointer: "rules", wantErr: true},
+		{pointer: "/a~2", wantErr: true},
+		{pointer: "/a~", wantErr: true},
+	}
+	for _, tc := range testcases {
+		got, err := ParseJSONPointer(tc.pointer)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("expected an error for %q", tc.pointer)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", tc.pointer, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("expected %q to be parsed as %q, got %q", tc.pointer, tc.want, got)
+		}
+	}
+}
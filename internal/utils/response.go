@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 )
@@ -15,3 +16,38 @@ func ResponseErrorWasStatusCode(err error, statusCode int) bool {
 	var responseErr *azcore.ResponseError
 	return errors.As(err, &responseErr) && responseErr.StatusCode == statusCode
 }
+
+// ResponseErrorWasTransient returns whether the request failed with a status which can succeed when it's retried, a
+// timeout, a conflict, throttling or a server error.
+func ResponseErrorWasTransient(err error) bool {
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) {
+		return false
+	}
+	switch responseErr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return true
+	}
+	return responseErr.StatusCode >= http.StatusInternalServerError
+}
+
+func ResponseErrorWasErrorCode(err error, errorCode string) bool {
+	var responseErr *azcore.ResponseError
+	return errors.As(err, &responseErr) && strings.EqualFold(responseErr.ErrorCode, errorCode)
+}
+
+// ResponseETag returns the entity tag of a resource, from the `ETag` header of the response or, when the service
+// doesn't send one, from the `@odata.etag` of its body. It returns an empty string when there's none.
+func ResponseETag(resp *http.Response, body interface{}) string {
+	if resp != nil {
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			return etag
+		}
+	}
+	if bodyMap, ok := body.(map[string]interface{}); ok {
+		if etag, ok := bodyMap["@odata.etag"].(string); ok {
+			return etag
+		}
+	}
+	return ""
+}
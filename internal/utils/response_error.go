@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// ResponseErrorDetails is the error envelope returned by the Verified ID APIs, together with the IDs used to trace the
+// request on the service side.
+type ResponseErrorDetails struct {
+	StatusCode    int
+	Code          string
+	Message       string
+	InnerCode     string
+	InnerMessage  string
+	Target        string
+	RequestId     string
+	CorrelationId string
+}
+
+// responseErrorEnvelope covers both the Verified ID envelope, with the request ID at the top level and an `innererror`
+// with a `target`, and the Microsoft Graph envelope, with the request IDs in the `innerError`.
+type responseErrorEnvelope struct {
+	RequestId string `json:"requestId"`
+	Error     *struct {
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		Target     string `json:"target"`
+		InnerError *struct {
+			Code            string `json:"code"`
+			Message         string `json:"message"`
+			Target          string `json:"target"`
+			RequestId       string `json:"request-id"`
+			ClientRequestId string `json:"client-request-id"`
+		} `json:"innererror"`
+	} `json:"error"`
+}
+
+// ParseResponseError decodes the error envelope of a failed response. It returns false if err isn't an
+// azcore.ResponseError, a body which isn't a known envelope only fills in the status code and the request IDs.
+func ParseResponseError(err error) (*ResponseErrorDetails, bool) {
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) {
+		return nil, false
+	}
+
+	details := &ResponseErrorDetails{
+		StatusCode: responseErr.StatusCode,
+		Code:       responseErr.ErrorCode,
+	}
+	resp := responseErr.RawResponse
+	if resp == nil {
+		return details, true
+	}
+
+	details.RequestId = firstHeader(resp.Header, "request-id", "x-ms-request-id")
+	details.CorrelationId = resp.Header.Get("x-ms-correlation-request-id")
+	if resp.Request != nil && details.CorrelationId == "" {
+		details.CorrelationId = resp.Request.Header.Get("x-ms-correlation-request-id")
+	}
+
+	payload, err := runtime.Payload(resp)
+	if err != nil || len(payload) == 0 {
+		return details, true
+	}
+	var envelope responseErrorEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil || envelope.Error == nil {
+		return details, true
+	}
+
+	if envelope.Error.Code != "" {
+		details.Code = envelope.Error.Code
+	}
+	details.Message = strings.TrimSpace(envelope.Error.Message)
+	details.Target = envelope.Error.Target
+	if envelope.RequestId != "" {
+		details.RequestId = envelope.RequestId
+	}
+	if inner := envelope.Error.InnerError; inner != nil {
+		details.InnerCode = inner.Code
+		details.InnerMessage = strings.TrimSpace(inner.Message)
+		if inner.Target != "" {
+			details.Target = inner.Target
+		}
+		if details.RequestId == "" {
+			details.RequestId = inner.RequestId
+		}
+		if details.CorrelationId == "" {
+			details.CorrelationId = inner.ClientRequestId
+		}
+	}
+	return details, true
+}
+
+// ErrorCodes returns the error code and the inner error code, the most specific one first.
+func (d ResponseErrorDetails) ErrorCodes() []string {
+	codes := make([]string, 0, 2)
+	for _, code := range []string{d.InnerCode, d.Code} {
+		if code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+func firstHeader(header http.Header, names ...string) string {
+	for _, name := range names {
+		if v := header.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestParseResponseError(t *testing.T) {
+	testcases := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		body    string
+		want    ResponseErrorDetails
+	}{
+		{
+			name:    "verified id envelope",
+			status:  http.StatusBadRequest,
+			headers: map[string]string{"x-ms-correlation-request-id": "corr-1"},
+			body:    `{"requestId":"req-1","error":{"code":"badRequest","message":"The request is invalid.","innererror":{"code":"badOrMissingField","message":"The card title is missing.","target":"displays[0].card.title"}}}`,
+			want: ResponseErrorDetails{
+				StatusCode:    http.StatusBadRequest,
+				Code:          "badRequest",
+				Message:       "The request is invalid.",
+				InnerCode:     "badOrMissingField",
+				InnerMessage:  "The card title is missing.",
+				Target:        "displays[0].card.title",
+				RequestId:     "req-1",
+				CorrelationId: "corr-1",
+			},
+		},
+		{
+			name:   "graph envelope",
+			status: http.StatusForbidden,
+			body:   `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges.","innerError":{"request-id":"req-2","client-request-id":"corr-2"}}}`,
+			want: ResponseErrorDetails{
+				StatusCode:    http.StatusForbidden,
+				Code:          "Authorization_RequestDenied",
+				Message:       "Insufficient privileges.",
+				RequestId:     "req-2",
+				CorrelationId: "corr-2",
+			},
+		},
+		{
+			name:    "not json",
+			status:  http.StatusBadGateway,
+			headers: map[string]string{"request-id": "req-3"},
+			body:    `<html>Bad Gateway</html>`,
+			want: ResponseErrorDetails{
+				StatusCode: http.StatusBadGateway,
+				RequestId:  "req-3",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "https://verifiedid.did.msidentity.com/v1.0/verifiableCredentials/authorities", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp := &http.Response{
+				StatusCode: tc.status,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tc.body)),
+				Request:    req,
+			}
+			for key, value := range tc.headers {
+				resp.Header.Set(key, value)
+			}
+
+			got, ok := ParseResponseError(runtime.NewResponseError(resp))
+			if !ok {
+				t.Fatal("expected a response error")
+			}
+			if !reflect.DeepEqual(*got, tc.want) {
+				t.Fatalf("expected %+v, got %+v", tc.want, *got)
+			}
+		})
+	}
+}
+
+func TestParseResponseError_OtherError(t *testing.T) {
+	if _, ok := ParseResponseError(errors.New("context deadline exceeded")); ok {
+		t.Fatal("expected other errors not to be parsed")
+	}
+}
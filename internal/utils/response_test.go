@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+func TestResponseErrorWasTransient(t *testing.T) {
+	testcases := []struct {
+		statusCode int
+		expected   bool
+	}{
+		{statusCode: http.StatusBadRequest, expected: false},
+		{statusCode: http.StatusForbidden, expected: false},
+		{statusCode: http.StatusNotFound, expected: false},
+		{statusCode: http.StatusRequestTimeout, expected: true},
+		{statusCode: http.StatusConflict, expected: true},
+		{statusCode: http.StatusTooManyRequests, expected: true},
+		{statusCode: http.StatusInternalServerError, expected: true},
+		{statusCode: http.StatusServiceUnavailable, expected: true},
+	}
+	for _, tc := range testcases {
+		err := &azcore.ResponseError{StatusCode: tc.statusCode}
+		if actual := ResponseErrorWasTransient(err); actual != tc.expected {
+			t.Errorf("%d: expected %t, got %t", tc.statusCode, tc.expected, actual)
+		}
+	}
+	if ResponseErrorWasTransient(errors.New("connection refused")) {
+		t.Errorf("expected an error without a response not to be transient")
+	}
+}
+
+func TestResponseETag(t *testing.T) {
+	withHeader := &http.Response{Header: http.Header{"Etag": []string{`W/"2"`}}}
+	withoutHeader := &http.Response{Header: http.Header{}}
+	body := map[string]interface{}{"@odata.etag": `W/"1"`}
+
+	testcases := []struct {
+		name     string
+		resp     *http.Response
+		body     interface{}
+		expected string
+	}{
+		{name: "header", resp: withHeader, body: body, expected: `W/"2"`},
+		{name: "body", resp: withoutHeader, body: body, expected: `W/"1"`},
+		{name: "no response", resp: nil, body: body, expected: `W/"1"`},
+		{name: "none", resp: withoutHeader, body: map[string]interface{}{"id": "1"}, expected: ""},
+		{name: "list", resp: withoutHeader, body: []interface{}{}, expected: ""},
+	}
+	for _, tc := range testcases {
+		if actual := ResponseETag(tc.resp, tc.body); actual != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.expected, actual)
+		}
+	}
+}
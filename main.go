@@ -5,8 +5,10 @@ import (
 	"flag"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/mjendza/terraform-provider-verifiedid/internal/clients"
 	"github.com/mjendza/terraform-provider-verifiedid/internal/provider"
+	"github.com/mjendza/terraform-provider-verifiedid/version"
 )
 
 // Run the docs generation tool, check its repository for more information on how it works and how docs
@@ -21,12 +23,28 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		Address: "registry.terraform.io/mjendza/verifiedid",
-		Debug:   debug,
+	ctx := context.Background()
+
+	// The tracing is configured by the standard OTEL_* environment variables, it is disabled when they are not set.
+	tp, shutdownTracing, err := clients.NewTracerProvider(ctx, version.ProviderVersion)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(), opts)
+	err = tf6server.Serve("registry.terraform.io/mjendza/verifiedid", provider.NewProviderServer(tp), serveOpts...)
+
+	clients.FlushTrafficLogs()
+
+	if shutdownTracing != nil {
+		if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+			log.Printf("[ERROR] Failed to flush the traces: %v", shutdownErr)
+		}
+	}
 	if err != nil {
 		log.Fatal(err.Error())
 	}